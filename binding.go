@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// minSemiEmpiricalA is the lightest mass number the semi-empirical mass formula is compared for
+const minSemiEmpiricalA = 3
+
+// bindingCommand prints the mass defect and binding energy of a nuclide next to the semi-empirical mass formula,
+// or plots the binding energy per nucleon of every nuclide
+func bindingCommand(args []string) error {
+	fs := flag.NewFlagSet("binding", flag.ExitOnError)
+	args = parseArgs(fs, args)
+	if len(args) > 1 {
+		return errors.New("usage: atomic binding [nuclide], e.g. atomic binding Fe-56")
+	}
+
+	if len(args) == 0 {
+		if err := loadData(); err != nil {
+			return err
+		}
+		var nuclides []elements.Nuclide
+		for _, n := range elements.NuclideTable {
+			nuclides = append(nuclides, n)
+		}
+		r := elements.NewRenderer(os.Stdout, elements.StylePlain)
+		fmt.Fprintln(r.Out)
+		r.BindingEnergyPlot(nuclides)
+		fmt.Fprintln(r.Out)
+		return nil
+	}
+
+	n, err := parseNuclide(args[0])
+	if err != nil {
+		return err
+	}
+	be := n.BindingEnergy()
+	semf := elements.SemiEmpiricalBindingEnergy(n.Z, n.A)
+
+	fmt.Println()
+	fmt.Printf("  %-15s : %s (%s)\n", "Nuclide", n.Name(), n.ToString())
+	fmt.Printf("  %-15s : %d (Z = %d, N = %d)\n", "Nucleons", n.A, n.Z, n.N)
+	fmt.Printf("  %-15s : %.6f u\n", "Atomic mass", n.Mass)
+	fmt.Printf("  %-15s : %.6f u\n", "Mass defect", be.MassDefect)
+	fmt.Printf("  %-15s : %.3f MeV\n", "Binding energy", be.Total)
+	fmt.Printf("  %-15s : %.3f MeV\n", "Per nucleon", be.PerNucleon)
+	fmt.Println()
+
+	// The liquid-drop model describes a nucleus large enough to have a volume and a surface
+	if n.A < minSemiEmpiricalA {
+		fmt.Printf("  The semi-empirical mass formula doesn't apply to nuclei lighter than A = %d\n", minSemiEmpiricalA)
+		fmt.Println()
+		return nil
+	}
+	fmt.Println("  Semi-empirical mass formula")
+	fmt.Printf("  %-15s : %+.3f MeV\n", "Volume", semf.Volume)
+	fmt.Printf("  %-15s : %+.3f MeV\n", "Surface", -semf.Surface)
+	fmt.Printf("  %-15s : %+.3f MeV\n", "Coulomb", -semf.Coulomb)
+	fmt.Printf("  %-15s : %+.3f MeV\n", "Asymmetry", -semf.Asymmetry)
+	fmt.Printf("  %-15s : %+.3f MeV\n", "Pairing", semf.Pairing)
+	fmt.Printf("  %-15s : %.3f MeV, %.3f MeV per nucleon\n", "Binding energy", semf.Total(), semf.Total()/float64(n.A))
+	fmt.Printf("  %-15s : %.3f MeV (%.1f%%)\n", "Difference", semf.Total()-be.Total, math.Abs(semf.Total()-be.Total)/be.Total*100)
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// chainCommand follows a nuclide down its decay chain and prints how much of each member there is after a time
+func chainCommand(args []string) error {
+	fs := flag.NewFlagSet("chain", flag.ExitOnError)
+	elapsed := fs.String("time", "", "Time elapsed with its unit, e.g. 1e9y or 30 d")
+	amount := fs.String("amount", "1g", "Starting amount of the parent: a mass (1g, 5 mg), moles (0.1 mol) or atoms (1e20)")
+	plot := fs.Bool("plot", false, "Plot the amount of each member from the start to the time instead of a table")
+	args = parseArgs(fs, args)
+	if len(args) != 1 || *elapsed == "" {
+		return errors.New("usage: atomic chain <nuclide> -time <time> [-amount 1g] [-plot], e.g. atomic chain U-238 -time 1e9y")
+	}
+
+	n, err := parseNuclide(args[0])
+	if err != nil {
+		return err
+	}
+	if n.Stable() {
+		return fmt.Errorf("%s is stable", n.ToString())
+	}
+	t, err := elements.ParseDuration(*elapsed)
+	if err != nil {
+		return err
+	}
+	if t <= 0 {
+		return fmt.Errorf("time %s must be positive", *elapsed)
+	}
+	atoms, unit, perUnit, err := parseAmount(*amount, n)
+	if err != nil {
+		return err
+	}
+	chain, err := elements.NewDecayChain(n)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	start := elements.FormatNumber(atoms/perUnit) + " " + unit
+	fmt.Printf("  %s decay chain after %s, from %s of %s\n\n", n.Name(), elements.FormatDuration(t), start, n.ToString())
+	if *plot {
+		plotChain(chain, t)
+	} else {
+		printChain(chain, t, atoms)
+	}
+	if len(chain.Missing) > 0 {
+		fmt.Printf("\n  Not followed, missing from the data: %s\n", strings.Join(chain.Missing, ", "))
+	}
+	fmt.Println()
+	return nil
+}
+
+// printChain prints the atoms, mass and activity of each member of the chain after t seconds
+func printChain(chain elements.DecayChain, t float64, atoms float64) {
+	amounts := chain.Amounts(t)
+	activities := chain.Activities(t)
+
+	fmt.Printf("  %-9s %-11s %-26s %-11s %-11s %s\n", "Nuclide", "Half-life", "Decay", "Atoms", "Mass (g)", "Activity (Bq)")
+	total := 0.0
+	for k, member := range chain.Members {
+		halfLife := "stable"
+		if !member.Stable() {
+			halfLife = elements.FormatDuration(member.HalfLife.Value)
+		}
+		var decays []string
+		for _, decay := range member.Decays {
+			decays = append(decays, formatBranch(decay))
+		}
+		mass := amounts[k] * atoms * member.Mass / avogadro
+		activity := activities[k] * atoms
+		total += activity
+		line := fmt.Sprintf("  %-9s %-11s %-26s %-11s %-11s %s", member.ToString(), halfLife, strings.Join(decays, ", "),
+			elements.FormatNumber(amounts[k]*atoms), elements.FormatNumber(mass), elements.FormatNumber(activity))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	fmt.Printf("\n  %-15s : %s Bq, %s Ci\n", "Total activity", elements.FormatNumber(total), elements.FormatNumber(total/curie))
+}
+
+// formatBranch writes a decay branch compactly, with its percentage only if it isn't the only branch (e.g. "β- 99.98%")
+func formatBranch(decay elements.Decay) string {
+	if decay.Branch == 1 {
+		return string(decay.Mode)
+	}
+	return fmt.Sprintf("%s %s%%", decay.Mode, branchPercent(decay))
+}
+
+// chainMarkers label the members of a chain in a plot
+const chainMarkers = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// plotChain plots the fraction of the parent's atoms in each member against time, from 0 to t, on a log scale
+// from 1 down to 10^-12. Members that never reach the bottom of the scale are left out.
+func plotChain(chain elements.DecayChain, t float64) {
+	const decades, rowsPerDecade = 12, 2
+	height := decades*rowsPerDecade + 1
+	width := 64
+	r := elements.NewRenderer(os.Stdout, elements.StylePlain)
+	if r.Width > 0 && r.Width-20 < width {
+		width = max(r.Width-20, 20)
+	}
+
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", width))
+	}
+	shown := make([]bool, len(chain.Members))
+	for x := 0; x < width; x++ {
+		amounts := chain.Amounts(t * float64(x) / float64(width-1))
+		for k, amount := range amounts {
+			if k >= len(chainMarkers) || amount <= 0 {
+				continue
+			}
+			row := int(math.Round(-math.Log10(amount) * rowsPerDecade))
+			if row < height {
+				grid[max(row, 0)][x] = rune(chainMarkers[k])
+				shown[k] = true
+			}
+		}
+	}
+
+	fmt.Println("  Fraction of the parent's atoms")
+	for i, line := range grid {
+		label := "       "
+		if i%rowsPerDecade == 0 {
+			label = fmt.Sprintf("%6s ", fmt.Sprintf("1e-%d", i/rowsPerDecade))
+			if i == 0 {
+				label = "     1 "
+			}
+		}
+		fmt.Println(strings.TrimRight("  "+label+"|"+string(line), " "))
+	}
+	fmt.Println("         +" + strings.Repeat("-", width))
+	end := elements.FormatDuration(t)
+	fmt.Printf("          0%s%s\n\n", strings.Repeat(" ", max(width-len([]rune(end))-1, 1)), end)
+
+	var legend []string
+	for k, member := range chain.Members {
+		if shown[k] {
+			legend = append(legend, fmt.Sprintf("%c %s", chainMarkers[k], member.ToString()))
+		}
+	}
+	for i := 0; i < len(legend); i += 6 {
+		fmt.Println("  " + strings.Join(legend[i:min(i+6, len(legend))], "   "))
+	}
+}
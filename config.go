@@ -0,0 +1,52 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// configCommand validates a written electron configuration and finds the atoms and ions it belongs to
+func configCommand(args []string) error {
+	fs := flag.NewFlagSet("config", flag.ExitOnError)
+	args = parseArgs(fs, args)
+	if len(args) == 0 {
+		return errors.New(`usage: atomic config "<configuration>", e.g. atomic config "[Ne] 3s2 3p4"`)
+	}
+
+	configuration, err := elements.ParseElectronConfiguration(strings.Join(args, " "))
+	if err != nil {
+		return err
+	}
+	if err := loadData(); err != nil {
+		return err
+	}
+
+	electrons := configuration.Electrons()
+	fmt.Println()
+	fmt.Printf("  %-15s : %s\n", "Configuration", configuration.ToString())
+	fmt.Printf("  %-15s : %s\n", "Abbreviated", configuration.Abbreviated())
+	fmt.Printf("  %-15s : %d\n", "Electrons", electrons)
+	fmt.Printf("  %-15s : %s\n", "Term", configuration.GroundTerm().ToString())
+
+	var names []string
+	for _, el := range configuration.Species() {
+		names = append(names, el.ToString())
+	}
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	fmt.Printf("  %-15s : %s\n", "Ground state of", strings.Join(names, ", "))
+
+	// An excited state is reported against the neutral atom with as many electrons
+	for _, el := range elements.ElementTable {
+		if el.Number == electrons && !configuration.IsGroundState(el.Number) {
+			fmt.Printf("  %-15s : excited state of %s, whose ground state is %s\n", "State", el.Symbol, el.GetElectronConfiguration().Abbreviated())
+		}
+	}
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// dataLayer is one source of CSV data; layers later in a list take precedence over earlier ones
+type dataLayer struct {
+	name string
+	data string
+}
+
+// dataLayers returns the embedded data for file, then the copy in the user's config directory
+// ($XDG_CONFIG_HOME/atomic on Linux) if there is one, then the file given on the command line.
+func dataLayers(file string, embedded string, override string) ([]dataLayer, error) {
+	layers := []dataLayer{{name: "data/" + file, data: embedded}}
+
+	if dir, err := os.UserConfigDir(); err == nil {
+		path := filepath.Join(dir, "atomic", file)
+		b, err := os.ReadFile(path)
+		if err == nil {
+			layers = append(layers, dataLayer{name: path, data: string(b)})
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	if override != "" {
+		b, err := os.ReadFile(override)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, dataLayer{name: override, data: string(b)})
+	}
+
+	return layers, nil
+}
+
+// loadData loads every element layer and then every molecule, emission line and nuclide layer, so that user
+// molecules, lines and nuclides are checked against user elements. It stops at the first layer with problems.
+// Entries that a user layer replaces are listed on stderr.
+func loadData() error {
+	elementLayers, err := dataLayers("elements.csv", elementsCSV, *elementsFile)
+	if err != nil {
+		return err
+	}
+	moleculeLayers, err := dataLayers("molecules.csv", moleculesCSV, *moleculesFile)
+	if err != nil {
+		return err
+	}
+	lineLayers, err := dataLayers("lines.csv", linesCSV, *linesFile)
+	if err != nil {
+		return err
+	}
+	nuclideLayers, err := dataLayers("nuclides.csv", nuclidesCSV, *nuclidesFile)
+	if err != nil {
+		return err
+	}
+
+	for _, layer := range elementLayers {
+		els, err := elements.ReadElements(layer.data)
+		if elements.IsInvalid(err) {
+			return fmt.Errorf("loading elements from %s: %w", layer.name, err)
+		}
+		printOverrides(layer, elements.MergeElements(els))
+	}
+	for _, layer := range moleculeLayers {
+		compounds, err := elements.ReadMolecules(layer.data)
+		if elements.IsInvalid(err) {
+			return fmt.Errorf("loading molecules from %s: %w", layer.name, err)
+		}
+		printOverrides(layer, elements.MergeMolecules(compounds))
+	}
+	for _, layer := range lineLayers {
+		lines, err := elements.ReadLines(layer.data)
+		if elements.IsInvalid(err) {
+			return fmt.Errorf("loading emission lines from %s: %w", layer.name, err)
+		}
+		printOverrides(layer, elements.MergeLines(lines))
+	}
+	for _, layer := range nuclideLayers {
+		nuclides, err := elements.ReadNuclides(layer.data)
+		if elements.IsInvalid(err) {
+			return fmt.Errorf("loading nuclides from %s: %w", layer.name, err)
+		}
+		printOverrides(layer, elements.MergeNuclides(nuclides))
+	}
+	return nil
+}
+
+// printOverrides lists on stderr the entries of earlier layers that a layer replaced, one per line
+func printOverrides(layer dataLayer, overridden []string) {
+	sort.Strings(overridden)
+	for _, key := range overridden {
+		fmt.Fprintf(os.Stderr, "%s: overrides %s\n", layer.name, key)
+	}
+}
+
+// dataCommand implements `atomic data check`, which lints every data layer and reports overridden entries
+func dataCommand(args []string) error {
+	if len(args) == 0 || args[0] != "check" {
+		return errors.New("usage: atomic data check [-elements-file file] [-molecules-file file] [-lines-file file] [-nuclides-file file]")
+	}
+
+	fs := flag.NewFlagSet("data check", flag.ExitOnError)
+	fs.StringVar(elementsFile, "elements-file", *elementsFile, "Extra elements CSV to check")
+	fs.StringVar(moleculesFile, "molecules-file", *moleculesFile, "Extra molecules CSV to check")
+	fs.StringVar(linesFile, "lines-file", *linesFile, "Extra emission lines CSV to check")
+	fs.StringVar(nuclidesFile, "nuclides-file", *nuclidesFile, "Extra nuclides CSV to check")
+	fs.Parse(args[1:])
+
+	elementLayers, err := dataLayers("elements.csv", elementsCSV, *elementsFile)
+	if err != nil {
+		return err
+	}
+	moleculeLayers, err := dataLayers("molecules.csv", moleculesCSV, *moleculesFile)
+	if err != nil {
+		return err
+	}
+	lineLayers, err := dataLayers("lines.csv", linesCSV, *linesFile)
+	if err != nil {
+		return err
+	}
+	nuclideLayers, err := dataLayers("nuclides.csv", nuclidesCSV, *nuclidesFile)
+	if err != nil {
+		return err
+	}
+
+	// Valid rows of each layer are merged even when it has problems, so later layers are checked
+	// against the data that would be in effect
+	fmt.Println()
+	problems := 0
+	for _, layer := range elementLayers {
+		els, err := elements.ReadElements(layer.data)
+		problems += report(layer.name, len(els), "elements", err)
+		reportOverrides(elements.MergeElements(els))
+	}
+	for _, layer := range moleculeLayers {
+		if len(elements.ElementTable) == 0 {
+			fmt.Printf("  %s: skipped, no valid elements to check against\n", layer.name)
+			continue
+		}
+		compounds, err := elements.ReadMolecules(layer.data)
+		problems += report(layer.name, len(compounds), "molecules", err)
+		reportOverrides(elements.MergeMolecules(compounds))
+	}
+	for _, layer := range lineLayers {
+		if len(elements.ElementTable) == 0 {
+			fmt.Printf("  %s: skipped, no valid elements to check against\n", layer.name)
+			continue
+		}
+		lines, err := elements.ReadLines(layer.data)
+		problems += report(layer.name, len(lines), "emission lines", err)
+		reportOverrides(elements.MergeLines(lines))
+	}
+	for _, layer := range nuclideLayers {
+		if len(elements.ElementTable) == 0 {
+			fmt.Printf("  %s: skipped, no valid elements to check against\n", layer.name)
+			continue
+		}
+		nuclides, err := elements.ReadNuclides(layer.data)
+		problems += report(layer.name, len(nuclides), "nuclides", err)
+		reportOverrides(elements.MergeNuclides(nuclides))
+	}
+	fmt.Println()
+
+	if problems > 0 {
+		return fmt.Errorf("data check failed with %d problem(s)", problems)
+	}
+	return nil
+}
+
+// report prints the result of checking one file and returns the number of problems found; warnings aren't counted
+func report(name string, valid int, kind string, err error) int {
+	var dataErr *elements.DataError
+	if err == nil {
+		fmt.Printf("  %s: ok, %d %s\n", name, valid, kind)
+		return 0
+	}
+	if !errors.As(err, &dataErr) {
+		fmt.Printf("  %s: %v\n", name, err)
+		return 1
+	}
+	if dataErr.Invalid() {
+		fmt.Printf("  %s: %d valid %s, %d problem(s)\n", name, valid, kind, len(dataErr.Rows))
+	} else {
+		fmt.Printf("  %s: ok, %d %s, %d warning(s)\n", name, valid, kind, len(dataErr.Warnings))
+	}
+	for _, row := range dataErr.Rows {
+		fmt.Printf("    %s\n", row.Error())
+	}
+	for _, row := range dataErr.Warnings {
+		fmt.Printf("    warning: %s\n", row.Error())
+	}
+	return len(dataErr.Rows)
+}
+
+// reportOverrides prints the entries of earlier layers that a layer replaced
+func reportOverrides(overridden []string) {
+	if len(overridden) > 0 {
+		fmt.Printf("    overrides %d: %s\n", len(overridden), strings.Join(overridden, ", "))
+	}
+}
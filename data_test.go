@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// TestEmbeddedData checks that the bundled CSVs pass `atomic data check`
+func TestEmbeddedData(t *testing.T) {
+	els, err := elements.ReadElements(elementsCSV)
+	if elements.IsInvalid(err) {
+		t.Fatalf("data/elements.csv: %v", err)
+	}
+	elements.MergeElements(els)
+
+	if _, err := elements.ReadMolecules(moleculesCSV); elements.IsInvalid(err) {
+		t.Errorf("data/molecules.csv: %v", err)
+	}
+	if _, err := elements.ReadLines(linesCSV); elements.IsInvalid(err) {
+		t.Errorf("data/lines.csv: %v", err)
+	}
+	if _, err := elements.ReadNuclides(nuclidesCSV); elements.IsInvalid(err) {
+		t.Errorf("data/nuclides.csv: %v", err)
+	}
+}
+
+// TestIsomerMass checks that Tc-99m lies its 142.68 keV excitation energy above Tc-99
+func TestIsomerMass(t *testing.T) {
+	nuclides, err := elements.ReadNuclides(nuclidesCSV)
+	if elements.IsInvalid(err) {
+		t.Fatalf("data/nuclides.csv: %v", err)
+	}
+	masses := make(map[string]float64)
+	for _, n := range nuclides {
+		masses[n.ToString()] = n.Mass
+	}
+	if excitation := (masses["Tc-99m"] - masses["Tc-99"]) * elements.MeVPerU * 1000; excitation < 142.63 || excitation > 142.73 {
+		t.Errorf("Tc-99m lies %.2f keV above Tc-99, want 142.68 keV", excitation)
+	}
+}
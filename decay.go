@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+const (
+	avogadro = 6.02214076e23 // 1/mol
+	curie    = 3.7e10        // Bq
+)
+
+// amountUnits maps the units of an amount of a nuclide to their size in grams, or in moles or atoms for those units
+var amountUnits = map[string]float64{
+	"kg": 1e3, "g": 1, "mg": 1e-3, "ug": 1e-6, "µg": 1e-6, "ng": 1e-9,
+	"mol": 1, "mmol": 1e-3, "umol": 1e-6, "µmol": 1e-6,
+	"atoms": 1,
+}
+
+// parseAmount parses an amount such as "1g", "2.5 mg", "0.1 mol" or "1e20 atoms" and returns it as a number of atoms
+// of the nuclide, along with the unit it was given in and the number of atoms in one of that unit
+func parseAmount(s string, n elements.Nuclide) (atoms float64, unit string, perUnit float64, err error) {
+	s = strings.TrimSpace(s)
+	i := strings.LastIndexAny(s, "0123456789.") + 1
+	value, err := strconv.ParseFloat(s[:i], 64)
+	if err != nil || value < 0 {
+		return 0, "", 0, fmt.Errorf("%q is not an amount, write it like 1g, 5 mg, 0.1 mol or 1e20 atoms", s)
+	}
+	unit = strings.TrimSpace(s[i:])
+	if unit == "" {
+		unit = "atoms"
+	}
+	scale, ok := amountUnits[unit]
+	if !ok {
+		return 0, "", 0, fmt.Errorf("unknown unit %q in %q, use g, mg, µg, kg, mol or atoms", unit, s)
+	}
+
+	switch {
+	case unit == "atoms":
+		perUnit = 1
+	case strings.HasSuffix(unit, "mol"):
+		perUnit = scale * avogadro
+	default:
+		perUnit = scale / n.Mass * avogadro
+	}
+	return value * perUnit, unit, perUnit, nil
+}
+
+// decayCommand works out how much of a radioactive sample is left after a time, and its activity
+func decayCommand(args []string) error {
+	fs := flag.NewFlagSet("decay", flag.ExitOnError)
+	elapsed := fs.String("t", "", "Time elapsed with its unit, e.g. 5730y, 8 d or 1e9y")
+	amount := fs.String("amount", "1g", "Starting amount: a mass (1g, 5 mg), moles (0.1 mol) or atoms (1e20)")
+	args = parseArgs(fs, args)
+	if len(args) != 1 || *elapsed == "" {
+		return errors.New("usage: atomic decay <nuclide> -t <time> [-amount 1g], e.g. atomic decay C-14 -t 5730y")
+	}
+
+	n, err := parseNuclide(args[0])
+	if err != nil {
+		return err
+	}
+	if n.Stable() {
+		return fmt.Errorf("%s is stable", n.ToString())
+	}
+	t, err := elements.ParseDuration(*elapsed)
+	if err != nil {
+		return err
+	}
+	if t < 0 {
+		return fmt.Errorf("time %s is negative", *elapsed)
+	}
+	atoms, unit, perUnit, err := parseAmount(*amount, n)
+	if err != nil {
+		return err
+	}
+
+	fraction := n.Remaining(t)
+	remaining := atoms * fraction
+	lambda := n.DecayConstant()
+
+	fmt.Println()
+	fmt.Printf("  %-15s : %s (%s)\n", "Nuclide", n.Name(), n.ToString())
+	fmt.Printf("  %-15s : %s\n", "Half-life", elements.FormatDuration(n.HalfLife.Value))
+	halfLives := elements.FormatNumber(t / n.HalfLife.Value)
+	if halfLives == "1" {
+		fmt.Printf("  %-15s : %s, 1 half-life\n", "Time", elements.FormatDuration(t))
+	} else {
+		fmt.Printf("  %-15s : %s, %s half-lives\n", "Time", elements.FormatDuration(t), halfLives)
+	}
+	fmt.Println()
+
+	row := func(label, sep, start, end string) {
+		fmt.Println(strings.TrimRight(fmt.Sprintf("  %-15s %s %-16s %s", label, sep, start, end), " "))
+	}
+	row("", " ", "Start", "After "+elements.FormatDuration(t))
+	row("Amount", ":", elements.FormatNumber(atoms/perUnit)+" "+unit, elements.FormatNumber(remaining/perUnit)+" "+unit)
+	if unit != "atoms" {
+		row("Atoms", ":", elements.FormatNumber(atoms), elements.FormatNumber(remaining))
+	}
+	row("Activity", ":", elements.FormatNumber(lambda*atoms)+" Bq", elements.FormatNumber(lambda*remaining)+" Bq")
+	row("", " ", elements.FormatNumber(lambda*atoms/curie)+" Ci", elements.FormatNumber(lambda*remaining/curie)+" Ci")
+	fmt.Println()
+	fmt.Printf("  %-15s : %s%%\n", "Remaining", elements.FormatNumber(fraction*100))
+	fmt.Println()
+	return nil
+}
@@ -0,0 +1,129 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// BindingEnergy is how much less a nucleus weighs than its free protons and neutrons
+type BindingEnergy struct {
+	MassDefect float64 // u
+	Total      float64 // MeV
+	PerNucleon float64 // MeV
+}
+
+// BindingEnergy returns the nuclide's mass defect and binding energy from its atomic mass: Z protons and
+// electrons and N neutrons less the mass of the atom. The binding of the electrons is neglected.
+func (n Nuclide) BindingEnergy() BindingEnergy {
+	defect := float64(n.Z)*(ProtonMass+ElectronMass) + float64(n.N)*NeutronMass - n.Mass
+	return BindingEnergy{
+		MassDefect: defect,
+		Total:      defect * MeVPerU,
+		PerNucleon: defect * MeVPerU / float64(n.A),
+	}
+}
+
+// Coefficients of the semi-empirical mass formula, in MeV
+const (
+	semfVolume    = 15.75
+	semfSurface   = 17.8
+	semfCoulomb   = 0.711
+	semfAsymmetry = 23.7
+	semfPairing   = 11.18
+)
+
+// SemiEmpirical is the binding energy of a nucleus by the semi-empirical (Weizsäcker) mass formula, term by term, in MeV
+type SemiEmpirical struct {
+	Volume, Surface, Coulomb, Asymmetry, Pairing float64
+}
+
+// Total returns the binding energy, the volume term less the others, plus or minus pairing
+func (s SemiEmpirical) Total() float64 {
+	return s.Volume - s.Surface - s.Coulomb - s.Asymmetry + s.Pairing
+}
+
+// SemiEmpiricalBindingEnergy returns the binding energy of a nucleus of Z protons and A nucleons by the liquid-drop model:
+// B = aV A - aS A^(2/3) - aC Z(Z-1)/A^(1/3) - aA (A-2Z)²/A ± aP/√A, the pairing term adding for even Z and N
+// and subtracting for odd Z and N
+func SemiEmpiricalBindingEnergy(z, a int) SemiEmpirical {
+	fa, fz := float64(a), float64(z)
+	terms := SemiEmpirical{
+		Volume:    semfVolume * fa,
+		Surface:   semfSurface * math.Pow(fa, 2.0/3),
+		Coulomb:   semfCoulomb * fz * (fz - 1) / math.Cbrt(fa),
+		Asymmetry: semfAsymmetry * (fa - 2*fz) * (fa - 2*fz) / fa,
+	}
+	switch n := a - z; {
+	case z%2 == 0 && n%2 == 0:
+		terms.Pairing = semfPairing / math.Sqrt(fa)
+	case z%2 == 1 && n%2 == 1:
+		terms.Pairing = -semfPairing / math.Sqrt(fa)
+	}
+	return terms
+}
+
+// stableZ returns the atomic number of the most tightly bound nucleus of A nucleons by the mass formula
+func stableZ(a int) int {
+	fa := float64(a)
+	return int(math.Round(fa / (2 + semfCoulomb/(2*semfAsymmetry)*math.Pow(fa, 2.0/3))))
+}
+
+// BindingEnergyPlot plots the binding energy per nucleon of each nuclide against its mass number, with "*"
+// for the measured values and "·" for the mass formula along the valley of stability
+func (r *Renderer) BindingEnergyPlot(nuclides []Nuclide) {
+	const height, top = 19, 9.0 // Rows of half an MeV, and MeV per nucleon at the top row
+	maxA := 0
+	for _, n := range nuclides {
+		maxA = max(maxA, n.A)
+	}
+	if maxA == 0 {
+		return
+	}
+	width := 100
+	if r.Width > 0 && r.Width-12 < width {
+		width = max(r.Width-12, 40)
+	}
+
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", width))
+	}
+	column := func(a int) int { return (a - 1) * (width - 1) / max(maxA-1, 1) }
+	row := func(perNucleon float64) (int, bool) {
+		i := int(math.Round((top - perNucleon) / top * float64(height-1)))
+		return i, i >= 0 && i < height
+	}
+
+	for a := 2; a <= maxA; a++ {
+		z := max(stableZ(a), 1)
+		if i, ok := row(SemiEmpiricalBindingEnergy(z, a).Total() / float64(a)); ok {
+			grid[i][column(a)] = '·'
+		}
+	}
+	for _, n := range nuclides {
+		if i, ok := row(n.BindingEnergy().PerNucleon); ok {
+			grid[i][column(n.A)] = '*'
+		}
+	}
+
+	fmt.Fprintln(r.Out, "  MeV per nucleon        * measured   · semi-empirical mass formula")
+	for i, line := range grid {
+		label := "     "
+		if value := top - float64(i)*top/float64(height-1); i%2 == 0 {
+			label = fmt.Sprintf("%4.0f ", value)
+		}
+		fmt.Fprintln(r.Out, strings.TrimRight("  "+label+"|"+string(line), " "))
+	}
+	fmt.Fprintln(r.Out, "       +"+strings.Repeat("-", width))
+
+	// Mass number ticks every 50
+	ticks := []rune(strings.Repeat(" ", width+8))
+	for a := 50; a <= maxA; a += 50 {
+		label := fmt.Sprint(a)
+		if at := 8 + column(a) - len(label)/2; at+len(label) <= len(ticks) {
+			copy(ticks[at:], []rune(label))
+		}
+	}
+	fmt.Fprintln(r.Out, strings.TrimRight(string(ticks), " ")+"   A")
+}
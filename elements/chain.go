@@ -0,0 +1,145 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+)
+
+// chainLink is a decay branch from one member of a chain to another, by their indices
+type chainLink struct {
+	from, to int
+	branch   float64
+}
+
+// DecayChain is a nuclide and every descendant it decays into, solved by the Bateman equations.
+// Each member's amount is a sum of exponentials, one for each member it descends from.
+type DecayChain struct {
+	Members []Nuclide // Parent first, each member before its daughters
+	Missing []string  // Daughters that aren't in NuclideTable, where the chain is cut short
+	links   []chainLink
+	lambdas []float64   // Decay constant of each member, 1/s
+	coeffs  [][]float64 // coeffs[k][i] multiplies e^(-λi t) in the amount of member k
+}
+
+// NewDecayChain follows every decay branch of the parent through NuclideTable to the stable nuclides at the end
+func NewDecayChain(parent Nuclide) (DecayChain, error) {
+	var chain DecayChain
+
+	// Order the members so that each comes before its daughters, by a depth-first search
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int)
+	var order []Nuclide
+	missing := make(map[string]bool)
+	var visit func(n Nuclide) error
+	visit = func(n Nuclide) error {
+		switch state[n.ToString()] {
+		case visiting:
+			return fmt.Errorf("the decays of %s form a loop", n.ToString())
+		case done:
+			return nil
+		}
+		state[n.ToString()] = visiting
+		for _, decay := range n.Decays {
+			if decay.Daughter == "" {
+				continue
+			}
+			daughter, ok := NuclideTable[decay.Daughter]
+			if !ok {
+				if !missing[decay.Daughter] {
+					missing[decay.Daughter] = true
+					chain.Missing = append(chain.Missing, decay.Daughter)
+				}
+				continue
+			}
+			if err := visit(daughter); err != nil {
+				return err
+			}
+		}
+		state[n.ToString()] = done
+		order = append(order, n)
+		return nil
+	}
+	if err := visit(parent); err != nil {
+		return DecayChain{}, err
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		chain.Members = append(chain.Members, order[i])
+	}
+
+	index := make(map[string]int)
+	for i, n := range chain.Members {
+		index[n.ToString()] = i
+		// The solution divides by differences of decay constants, so equal ones are set slightly apart
+		lambda := n.DecayConstant()
+		for _, other := range chain.lambdas {
+			if lambda != 0 && math.Abs(lambda-other) < 1e-9*lambda {
+				lambda *= 1 + 1e-8
+			}
+		}
+		chain.lambdas = append(chain.lambdas, lambda)
+	}
+	for i, n := range chain.Members {
+		for _, decay := range n.Decays {
+			if to, ok := index[decay.Daughter]; ok {
+				chain.links = append(chain.links, chainLink{from: i, to: to, branch: decay.Branch})
+			}
+		}
+	}
+
+	// Bateman coefficients for one atom of the parent: a member fed by a parent holding c e^(-λi t)
+	// gains b λp c / (λk - λi) of that exponential, and its own exponential starts it from zero
+	n := len(chain.Members)
+	chain.coeffs = make([][]float64, n)
+	for k := range chain.coeffs {
+		chain.coeffs[k] = make([]float64, n)
+	}
+	chain.coeffs[0][0] = 1
+	for k := 1; k < n; k++ {
+		for _, link := range chain.links {
+			if link.to != k {
+				continue
+			}
+			for i, c := range chain.coeffs[link.from] {
+				if c != 0 && i != k {
+					chain.coeffs[k][i] += link.branch * chain.lambdas[link.from] * c / (chain.lambdas[k] - chain.lambdas[i])
+				}
+			}
+		}
+		for i, c := range chain.coeffs[k] {
+			if i != k {
+				chain.coeffs[k][k] -= c
+			}
+		}
+	}
+	return chain, nil
+}
+
+// Amounts returns the number of atoms of each member after t seconds, for one atom of the parent at the start.
+// The exponentials are summed as e^(-λt) - 1 with the starting amount added back, which keeps the stable
+// members and short times from cancelling away.
+func (c DecayChain) Amounts(t float64) []float64 {
+	amounts := make([]float64, len(c.Members))
+	for k, coeffs := range c.coeffs {
+		if k == 0 {
+			amounts[k] = 1
+		}
+		for i, coeff := range coeffs {
+			amounts[k] += coeff * math.Expm1(-c.lambdas[i]*t)
+		}
+		amounts[k] = math.Max(amounts[k], 0)
+	}
+	return amounts
+}
+
+// Activities returns the decays per second of each member after t seconds, for one atom of the parent at the start
+func (c DecayChain) Activities(t float64) []float64 {
+	activities := c.Amounts(t)
+	for k := range activities {
+		activities[k] *= c.lambdas[k]
+	}
+	return activities
+}
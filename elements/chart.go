@@ -0,0 +1,249 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ChartColorings are the ways the chart of nuclides can be coloured
+var ChartColorings = []string{"decay", "halflife"}
+
+// ChartOptions selects the window and colouring of a chart of nuclides
+type ChartOptions struct {
+	ColorBy   string          // One of ChartColorings, "decay" if empty
+	Z, N      [2]int          // Lowest and highest atomic number and neutron number shown
+	Highlight map[string]bool // Names of nuclides to mark (e.g. "C-13")
+}
+
+// chartKey is the mark and colour of one kind of cell in the chart
+type chartKey struct {
+	mark   rune
+	colour rgb
+	label  string
+}
+
+// decayKeys colour nuclides by their main decay mode, as on printed charts of nuclides
+var decayKeys = map[DecayMode]chartKey{
+	"":                 {'#', rgb{40, 40, 40}, "stable"},
+	BetaMinusDecay:     {'-', rgb{70, 130, 230}, "β-"},
+	BetaPlusDecay:      {'+', rgb{230, 70, 70}, "β+ or EC"},
+	ElectronCapture:    {'+', rgb{230, 70, 70}, "β+ or EC"},
+	AlphaDecay:         {'a', rgb{240, 220, 60}, "α"},
+	IsomericTransition: {'i', rgb{220, 220, 220}, "IT"},
+	SpontaneousFission: {'f', rgb{80, 200, 90}, "SF"},
+}
+
+// halfLifeBands mark half-lives in plain output, each up to its limit in seconds
+var halfLifeBands = []struct {
+	limit float64
+	mark  rune
+	label string
+}{
+	{1, '.', "< 1 s"},
+	{3600, ':', "< 1 h"},
+	{Year, 'o', "< 1 y"},
+	{1e6 * Year, 'O', "< 1 My"},
+	{math.Inf(1), '@', "longer"},
+}
+
+// Half-lives coloured from 1 µs to 10^18 s, about 3e10 years, on a log scale
+const chartLogMin, chartLogMax = -6.0, 18.0
+
+// chartKeyOf returns the mark and colour of a nuclide's cell
+func chartKeyOf(n Nuclide, colorBy string) chartKey {
+	if n.Stable() {
+		return decayKeys[""]
+	}
+	if colorBy == "halflife" {
+		key := chartKey{colour: gradient((math.Log10(n.HalfLife.Value) - chartLogMin) / (chartLogMax - chartLogMin))}
+		for _, band := range halfLifeBands {
+			if n.HalfLife.Value < band.limit {
+				key.mark, key.label = band.mark, band.label
+				break
+			}
+		}
+		return key
+	}
+	if key, ok := decayKeys[n.Decays[0].Mode]; ok {
+		return key
+	}
+	return chartKey{'?', noData, "other"}
+}
+
+// NuclideChart draws the Segrè chart of the nuclides in NuclideTable, neutron number across and atomic number up,
+// over the window in opts. Metastable states are left out. When the window is wider than r.Width, each cell
+// covers a square of several Z and N and shows a highlighted nuclide if it has one, otherwise its longest-lived
+// nuclide. Highlighted nuclides are marked with "*" on plain output and brackets on colour.
+func (r *Renderer) NuclideChart(opts ChartOptions) error {
+	if opts.ColorBy == "" {
+		opts.ColorBy = "decay"
+	}
+	if opts.ColorBy != "decay" && opts.ColorBy != "halflife" {
+		return fmt.Errorf("unknown colouring %q, expected one of: %s", opts.ColorBy, strings.Join(ChartColorings, ", "))
+	}
+	if opts.Z[0] > opts.Z[1] || opts.N[0] > opts.N[1] {
+		return fmt.Errorf("empty window Z %d-%d, N %d-%d", opts.Z[0], opts.Z[1], opts.N[0], opts.N[1])
+	}
+
+	// Two columns per cell keep the cells roughly square
+	const labelWidth = 10
+	scale := 1
+	if r.Width > 0 {
+		for labelWidth+2*((opts.N[1]-opts.N[0])/scale+1) > r.Width {
+			scale++
+		}
+	}
+	rows := (opts.Z[1]-opts.Z[0])/scale + 1
+	columns := (opts.N[1]-opts.N[0])/scale + 1
+
+	// Each cell keeps a highlighted nuclide, otherwise the longest-lived one
+	cells := make([][]*Nuclide, rows)
+	for i := range cells {
+		cells[i] = make([]*Nuclide, columns)
+	}
+	marked := make([][]bool, rows)
+	pinned := make([][]bool, rows)
+	for i := range marked {
+		marked[i] = make([]bool, columns)
+		pinned[i] = make([]bool, columns)
+	}
+	longer := func(a, b Nuclide) bool {
+		if a.Stable() != b.Stable() {
+			return a.Stable()
+		}
+		return a.HalfLife.Value > b.HalfLife.Value
+	}
+	for _, n := range NuclideTable {
+		if n.Isomer || n.Z < opts.Z[0] || n.Z > opts.Z[1] || n.N < opts.N[0] || n.N > opts.N[1] {
+			continue
+		}
+		row, column := (n.Z-opts.Z[0])/scale, (n.N-opts.N[0])/scale
+		if cell := cells[row][column]; cell == nil || longer(n, *cell) {
+			n := n
+			cells[row][column] = &n
+		}
+	}
+	for name := range opts.Highlight {
+		n, ok := NuclideTable[name]
+		if !ok || n.Z < opts.Z[0] || n.Z > opts.Z[1] || n.N < opts.N[0] || n.N > opts.N[1] {
+			continue
+		}
+		row, column := (n.Z-opts.Z[0])/scale, (n.N-opts.N[0])/scale
+		// A highlighted ground state takes the cell, the longest-lived one if several share it
+		if cell := cells[row][column]; !n.Isomer && (!pinned[row][column] || longer(n, *cell)) {
+			cells[row][column] = &n
+			pinned[row][column] = true
+		}
+		marked[row][column] = true
+	}
+
+	if scale > 1 {
+		fmt.Fprintf(r.Out, "  Each cell covers %d values of Z by %d of N and shows a highlighted or its longest-lived nuclide;\n  zoom in with -z and -n to see them all\n\n", scale, scale)
+	}
+	fmt.Fprintln(r.Out, "  Z")
+	used := make(map[string]chartKey)
+	for row := rows - 1; row >= 0; row-- {
+		z := opts.Z[0] + row*scale
+		label := strings.Repeat(" ", labelWidth-1)
+		if scale == 1 || row%2 == 0 {
+			symbol, _ := symbolOf(z)
+			label = fmt.Sprintf("  %3d %-2s ", z, symbol)
+		}
+
+		var sb strings.Builder
+		sb.WriteString(label + "|")
+		for column := 0; column < columns; column++ {
+			n := cells[row][column]
+			if n == nil {
+				sb.WriteString("  ")
+				continue
+			}
+			key := chartKeyOf(*n, opts.ColorBy)
+			used[key.label] = key
+			switch {
+			case r.Color && marked[row][column]:
+				sb.WriteString(key.colour.background(r.Depth) + "\x1b[1m[]\x1b[0m")
+			case r.Color:
+				sb.WriteString(key.colour.background(r.Depth) + "  \x1b[0m")
+			case marked[row][column]:
+				sb.WriteString("* ")
+			default:
+				sb.WriteString(string(key.mark) + " ")
+			}
+		}
+		fmt.Fprintln(r.Out, strings.TrimRight(sb.String(), " "))
+	}
+
+	// Neutron numbers every five cells
+	fmt.Fprintln(r.Out, strings.Repeat(" ", labelWidth-1)+"+"+strings.Repeat("-", 2*columns))
+	ticks := []rune(strings.Repeat(" ", labelWidth+2*columns+4))
+	for column := 0; column < columns; column += 5 {
+		label := fmt.Sprint(opts.N[0] + column*scale)
+		copy(ticks[labelWidth+2*column:], []rune(label))
+	}
+	fmt.Fprintln(r.Out, strings.TrimRight(string(ticks), " ")+"  N")
+	fmt.Fprintln(r.Out)
+
+	r.chartLegend(opts, used)
+	return nil
+}
+
+// chartLegend explains the marks or colours used in a chart
+func (r *Renderer) chartLegend(opts ChartOptions, used map[string]chartKey) {
+	var labels []string
+	if opts.ColorBy == "halflife" && r.Color {
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("  Half-life: %s ", FormatDuration(math.Pow(10, chartLogMin))))
+		const width = 32
+		for i := 0; i < width; i++ {
+			sb.WriteString(gradient(float64(i)/(width-1)).background(r.Depth) + " \x1b[0m")
+		}
+		sb.WriteString(fmt.Sprintf(" %s   %s  \x1b[0m stable", FormatDuration(math.Pow(10, chartLogMax)), decayKeys[""].colour.background(r.Depth)))
+		fmt.Fprintln(r.Out, sb.String())
+	} else {
+		order := []string{"stable", "β-", "β+ or EC", "α", "IT", "SF", "other"}
+		if opts.ColorBy == "halflife" {
+			order = []string{"stable"}
+			for _, band := range halfLifeBands {
+				order = append(order, band.label)
+			}
+		}
+		for _, label := range order {
+			key, ok := used[label]
+			if !ok {
+				continue
+			}
+			if r.Color {
+				labels = append(labels, fmt.Sprintf("%s  \x1b[0m %s", key.colour.background(r.Depth), label))
+			} else {
+				labels = append(labels, fmt.Sprintf("%c %s", key.mark, label))
+			}
+		}
+		fmt.Fprintln(r.Out, "  "+strings.Join(labels, "   "))
+	}
+
+	if len(opts.Highlight) > 0 {
+		mark := "*"
+		if r.Color {
+			mark = "[]"
+		}
+		var names []string
+		for _, n := range sortedNuclides(opts.Highlight) {
+			names = append(names, n.ToString())
+		}
+		fmt.Fprintf(r.Out, "  %s in the formula: %s\n", mark, strings.Join(names, ", "))
+	}
+}
+
+// sortedNuclides returns the named nuclides of NuclideTable in chart order
+func sortedNuclides(names map[string]bool) []Nuclide {
+	var nuclides []Nuclide
+	for name := range names {
+		if n, ok := NuclideTable[name]; ok {
+			nuclides = append(nuclides, n)
+		}
+	}
+	sortNuclides(nuclides)
+	return nuclides
+}
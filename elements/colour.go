@@ -0,0 +1,164 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+)
+
+// ColorDepth is the number of colours a terminal can display
+type ColorDepth int
+
+const (
+	Colors16 ColorDepth = iota
+	Colors256
+	TrueColor
+)
+
+// DetectColorDepth guesses the colour support of the terminal from the environment
+func DetectColorDepth() ColorDepth {
+	colorterm := os.Getenv("COLORTERM")
+	if colorterm == "truecolor" || colorterm == "24bit" || os.Getenv("WT_SESSION") != "" {
+		return TrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return Colors256
+	}
+	return Colors16
+}
+
+// ansiColours are the standard xterm values of the 16 basic terminal colours
+var ansiColours = [16]rgb{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// rgb is a 24-bit terminal colour
+type rgb struct {
+	r, g, b uint8
+}
+
+// noData is the colour of cells whose property is missing from the data
+var noData = rgb{88, 88, 88}
+
+// gradientStops are the colours of the heatmap scale from its lowest to its highest value
+var gradientStops = []rgb{
+	{68, 1, 84},
+	{59, 82, 139},
+	{33, 145, 140},
+	{94, 201, 98},
+	{253, 231, 37},
+}
+
+// gradientBasic are the basic colours that stand for the heatmap scale on a 16-colour terminal, lowest first:
+// magenta, blue, cyan, green, bright green and bright yellow. Grey is kept for noData.
+var gradientBasic = []int{5, 4, 6, 2, 10, 11}
+
+// noDataBasic is the basic colour of noData, bright black
+const noDataBasic = 8
+
+// gradient returns the colour at position t (0 to 1) along the heatmap scale
+func gradient(t float64) rgb {
+	t = math.Max(0, math.Min(1, t))
+	pos := t * float64(len(gradientStops)-1)
+	i := int(pos)
+	if i >= len(gradientStops)-1 {
+		return gradientStops[len(gradientStops)-1]
+	}
+	return mix(gradientStops[i], gradientStops[i+1], pos-float64(i))
+}
+
+// mix returns the colour a fraction f of the way from a to b
+func mix(a, b rgb, f float64) rgb {
+	channel := func(x, y uint8) uint8 {
+		return uint8(math.Round(float64(x) + (float64(y)-float64(x))*f))
+	}
+	return rgb{channel(a.r, b.r), channel(a.g, b.g), channel(a.b, b.b)}
+}
+
+// gradientPosition returns the position (0 to 1) of c along the heatmap scale, if c is one of its colours
+func gradientPosition(c rgb) (float64, bool) {
+	for i := 0; i+1 < len(gradientStops); i++ {
+		a, b := gradientStops[i], gradientStops[i+1]
+		// The channel that changes most gives the fraction, and the colour must match the mix there
+		from, to, value := float64(a.r), float64(b.r), float64(c.r)
+		if math.Abs(float64(b.g)-float64(a.g)) > math.Abs(to-from) {
+			from, to, value = float64(a.g), float64(b.g), float64(c.g)
+		}
+		if math.Abs(float64(b.b)-float64(a.b)) > math.Abs(to-from) {
+			from, to, value = float64(a.b), float64(b.b), float64(c.b)
+		}
+		f := (value - from) / (to - from)
+		if f < 0 || f > 1 {
+			continue
+		}
+		// Each channel of the mix is rounded, so allow one step either way
+		m := mix(a, b, f)
+		near := func(x, y uint8) bool { return math.Abs(float64(x)-float64(y)) <= 1 }
+		if near(m.r, c.r) && near(m.g, c.g) && near(m.b, c.b) {
+			return (float64(i) + f) / float64(len(gradientStops)-1), true
+		}
+	}
+	return 0, false
+}
+
+// cube returns the nearest entry of the 6x6x6 colour cube of a 256-colour terminal
+func (c rgb) cube() int {
+	level := func(v uint8) int {
+		return int(math.Round(float64(v) / 255 * 5))
+	}
+	return 16 + 36*level(c.r) + 6*level(c.g) + level(c.b)
+}
+
+// basic returns the index of the nearest of the 16 basic terminal colours. The heatmap scale is split into the
+// steps of gradientBasic, and grey is left to noData, so that missing values stand out from low ones.
+func (c rgb) basic() int {
+	if c == noData {
+		return noDataBasic
+	}
+	if t, ok := gradientPosition(c); ok {
+		return gradientBasic[min(int(t*float64(len(gradientBasic))), len(gradientBasic)-1)]
+	}
+	best, bestDist := 0, math.Inf(1)
+	for i, a := range ansiColours {
+		if i == noDataBasic {
+			continue
+		}
+		dr, dg, db := float64(c.r)-float64(a.r), float64(c.g)-float64(a.g), float64(c.b)-float64(a.b)
+		if dist := dr*dr + dg*dg + db*db; dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// light reports whether dark text is more readable than light text on c
+func (c rgb) light() bool {
+	return 0.299*float64(c.r)+0.587*float64(c.g)+0.114*float64(c.b) > 140
+}
+
+// background returns the escape sequence that sets c as the background, with a readable foreground
+func (c rgb) background(depth ColorDepth) string {
+	switch depth {
+	case TrueColor:
+		return fmt.Sprintf("\x1b[%d;48;2;%d;%d;%dm", c.foreground(), c.r, c.g, c.b)
+	case Colors256:
+		return fmt.Sprintf("\x1b[%d;48;5;%dm", c.foreground(), c.cube())
+	}
+	i := c.basic()
+	if i < 8 {
+		return fmt.Sprintf("\x1b[%d;%dm", ansiColours[i].foreground(), 40+i)
+	}
+	return fmt.Sprintf("\x1b[%d;%dm", ansiColours[i].foreground(), 100+i-8)
+}
+
+// foreground returns the SGR code of black or bright white text, whichever reads better on c
+func (c rgb) foreground() int {
+	if c.light() {
+		return 30
+	}
+	return 97
+}
@@ -0,0 +1,39 @@
+package elements
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBasicGradient(t *testing.T) {
+	const samples = 60
+	seen := make(map[int]bool)
+	previous := -1
+	for i := 0; i <= samples; i++ {
+		c := gradient(float64(i) / samples)
+		basic := c.basic()
+		if basic == noData.basic() {
+			t.Errorf("gradient(%g) = %v maps to the no-data colour", float64(i)/samples, c)
+		}
+		if basic != previous && seen[basic] {
+			t.Errorf("gradient(%g) = %v returns to basic colour %d", float64(i)/samples, c, basic)
+		}
+		seen[basic] = true
+		previous = basic
+	}
+	if len(seen) != len(gradientBasic) {
+		t.Errorf("gradient uses %d basic colours, want %d", len(seen), len(gradientBasic))
+	}
+}
+
+func TestPeriodicTablePlainColorBy(t *testing.T) {
+	loadTestElements(t)
+	var out strings.Builder
+	r := NewRenderer(&out, StylePlain)
+	if err := r.PeriodicTable(Molecule{}, TableOptions{ColorBy: "category"}); err == nil {
+		t.Error("PeriodicTable drew a plain table for -color-by category")
+	}
+	if out.Len() != 0 {
+		t.Errorf("PeriodicTable wrote %d bytes before failing", out.Len())
+	}
+}
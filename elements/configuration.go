@@ -0,0 +1,142 @@
+package elements
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseElectronConfiguration parses a written electron configuration such as "1s2 2s2 2p6 3s1" or "[Ne] 3s2 3p4".
+// Subshells are separated by spaces or dots, a noble-gas core may start it, and a subshell without a count holds one electron.
+// Every subshell must exist (l < n), appear once and hold no more electrons than the Pauli principle allows.
+func ParseElectronConfiguration(s string) (ElectronConfiguration, error) {
+	configuration := ElectronConfiguration{}
+	seen := make(map[string]bool)
+
+	parts := strings.FieldsFunc(s, func(r rune) bool { return unicode.IsSpace(r) || r == '.' })
+	if len(parts) == 0 {
+		return configuration, fmt.Errorf("empty electron configuration")
+	}
+
+	for i, part := range parts {
+		if strings.HasPrefix(part, "[") {
+			if i > 0 {
+				return configuration, fmt.Errorf("noble-gas core %s must come first", part)
+			}
+			core, err := parseCore(part)
+			if err != nil {
+				return configuration, err
+			}
+			for _, subshell := range core.Subshells {
+				seen[subshell.Orbital] = true
+			}
+			configuration.Subshells = append(configuration.Subshells, core.Subshells...)
+			continue
+		}
+
+		subshell, err := parseSubshell(part)
+		if err != nil {
+			return configuration, err
+		}
+		if seen[subshell.Orbital] {
+			return configuration, fmt.Errorf("subshell %s appears more than once", subshell.Orbital)
+		}
+		seen[subshell.Orbital] = true
+		configuration.Subshells = append(configuration.Subshells, subshell)
+	}
+
+	return configuration, nil
+}
+
+// parseCore expands a noble-gas core such as [Ne]
+func parseCore(s string) (ElectronConfiguration, error) {
+	symbol := strings.TrimSuffix(strings.TrimPrefix(s, "["), "]")
+	for _, core := range nobleGasCores {
+		if core.symbol == symbol && s == "["+symbol+"]" {
+			return GenerateElectronConfiguration(core.number), nil
+		}
+	}
+	return ElectronConfiguration{}, fmt.Errorf("invalid noble-gas core %s", s)
+}
+
+// maxShell is the highest principal quantum number a written configuration may use
+const maxShell = 7
+
+// parseSubshell parses one subshell such as 4d10 into its orbital and electron count
+func parseSubshell(s string) (Subshell, error) {
+	letter := strings.IndexFunc(s, unicode.IsLetter)
+	if letter <= 0 {
+		return Subshell{}, fmt.Errorf("invalid subshell %q, expected e.g. 3p4", s)
+	}
+	n, err := strconv.Atoi(s[:letter])
+	if err != nil || n < 1 {
+		return Subshell{}, fmt.Errorf("invalid shell in subshell %q", s)
+	}
+	// Ground states end at 7p, and Slater's rules only go as far as n = 7
+	if n > maxShell {
+		return Subshell{}, fmt.Errorf("shell %d in subshell %q is beyond n = %d", n, s, maxShell)
+	}
+	l := strings.IndexByte("spdf", s[letter])
+	if l < 0 {
+		return Subshell{}, fmt.Errorf("invalid subshell %q, expected s, p, d or f", s)
+	}
+	if l >= n {
+		return Subshell{}, fmt.Errorf("subshell %s%c does not exist, l must be less than n", s[:letter], s[letter])
+	}
+
+	orbital := s[:letter+1]
+	electrons := 1
+	if count := s[letter+1:]; count != "" {
+		electrons, err = strconv.Atoi(count)
+		if err != nil || electrons < 0 {
+			return Subshell{}, fmt.Errorf("invalid electron count in subshell %q", s)
+		}
+	}
+	if capacity := 2 * (2*l + 1); electrons > capacity {
+		return Subshell{}, fmt.Errorf("subshell %s holds at most %d electrons, not %d", orbital, capacity, electrons)
+	}
+	return Subshell{Orbital: orbital, Electrons: electrons}, nil
+}
+
+// IsGroundState reports whether the configuration is the ground state of the element with the given atomic number,
+// or of its ion with as many electrons
+func (ec ElectronConfiguration) IsGroundState(atomicNumber int) bool {
+	return ec.Isoelectronic(IonConfiguration(atomicNumber, atomicNumber-ec.Electrons()))
+}
+
+// Species returns the neutral atom and the ions that the configuration is the ground state of, the neutral atom first
+// and then by the size of the charge. Only ions whose charge is one of the element's oxidation states are considered.
+func (ec ElectronConfiguration) Species() []Element {
+	var species []Element
+	for _, el := range ElementTable {
+		charge := el.Number - ec.Electrons()
+		if !hasOxidationState(el, charge) || !ec.IsGroundState(el.Number) {
+			continue
+		}
+		el.Charge = charge
+		species = append(species, el)
+	}
+	sort.Slice(species, func(i, j int) bool {
+		a, b := abs(species[i].Charge), abs(species[j].Charge)
+		if a != b {
+			return a < b
+		}
+		return species[i].Charge > species[j].Charge
+	})
+	return species
+}
+
+// hasOxidationState reports whether an element forms ions of the charge, counting the neutral atom
+func hasOxidationState(el Element, charge int) bool {
+	if charge == 0 {
+		return true
+	}
+	for _, state := range el.OxidationStates {
+		if state == charge {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package elements
+
+import "testing"
+
+func TestSpecies(t *testing.T) {
+	loadTestElements(t)
+	tests := []struct {
+		configuration string
+		want          []string
+	}{
+		{"[Xe] 4f6", []string{"Sm+2", "Eu+3"}},
+		{"[Xe] 4f7", []string{"Eu+2", "Gd+3"}},
+		{"[Ar] 3d5", []string{"Mn+2", "Fe+3"}},
+		{"[Rn] 5f2", []string{"U+4"}},
+	}
+	for _, test := range tests {
+		ec, err := ParseElectronConfiguration(test.configuration)
+		if err != nil {
+			t.Fatalf("ParseElectronConfiguration(%q): %v", test.configuration, err)
+		}
+		found := make(map[string]bool)
+		for _, el := range ec.Species() {
+			found[el.ToString()] = true
+		}
+		for _, want := range test.want {
+			if !found[want] {
+				t.Errorf("Species of %s is missing %s", test.configuration, want)
+			}
+		}
+	}
+}
+
+func TestIsGroundState(t *testing.T) {
+	tests := []struct {
+		configuration string
+		z             int
+		want          bool
+	}{
+		{"[Xe] 4f6", 63, true},               // Eu+3
+		{"[Kr] 5s2 4d10 5p5 4f7", 63, false}, // Eu+3 with a 5p electron taken instead of 4f
+		{"[Ar] 3d6", 26, true},               // Fe+2
+		{"[Ar] 4s2 3d4", 26, false},          // Fe+2 with 3d electrons taken instead of 4s
+		{"[Rn] 5f2", 92, true},               // U+4
+	}
+	for _, test := range tests {
+		ec, err := ParseElectronConfiguration(test.configuration)
+		if err != nil {
+			t.Fatalf("ParseElectronConfiguration(%q): %v", test.configuration, err)
+		}
+		if got := ec.IsGroundState(test.z); got != test.want {
+			t.Errorf("%s IsGroundState(%d) = %v, want %v", test.configuration, test.z, got, test.want)
+		}
+	}
+}
+
+func TestParseElectronConfigurationErrors(t *testing.T) {
+	for _, s := range []string{"10s1", "8s2", "[Rn] 7s2 8p1", "2d1", "3p7", "4x2"} {
+		if ec, err := ParseElectronConfiguration(s); err == nil {
+			t.Errorf("ParseElectronConfiguration(%q) = %s, want an error", s, ec.ToString())
+		}
+	}
+	if _, err := ParseElectronConfiguration("[Rn] 7s2 5f14 6d10 7p6"); err != nil {
+		t.Errorf("ParseElectronConfiguration of Og: %v", err)
+	}
+}
@@ -0,0 +1,489 @@
+package elements
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RowError describes a single problem found in a data file
+type RowError struct {
+	Line    int    // Line number in the file, 0 if the problem isn't tied to a row
+	Column  string // Column name, empty if the problem concerns the whole row
+	Message string
+}
+
+func (e RowError) Error() string {
+	if e.Line == 0 {
+		return e.Message
+	}
+	if e.Column == "" {
+		return fmt.Sprintf("line %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("line %d, %s: %s", e.Line, e.Column, e.Message)
+}
+
+// DataError collects every problem found while reading a data file
+type DataError struct {
+	Rows     []RowError
+	Warnings []RowError // Problems that were resolved by a rule, such as a formula defined twice
+}
+
+func (e *DataError) Error() string {
+	var sb strings.Builder
+	switch len(e.Rows) {
+	case 0:
+		sb.WriteString(fmt.Sprintf("%d warning(s) in data", len(e.Warnings)))
+	case 1:
+		sb.WriteString("1 problem in data")
+	default:
+		sb.WriteString(fmt.Sprintf("%d problems in data", len(e.Rows)))
+	}
+	for _, row := range e.Rows {
+		sb.WriteString("\n  " + row.Error())
+	}
+	for _, row := range e.Warnings {
+		sb.WriteString("\n  warning: " + row.Error())
+	}
+	return sb.String()
+}
+
+// Invalid reports whether the data has problems, rather than only warnings
+func (e *DataError) Invalid() bool {
+	return len(e.Rows) > 0
+}
+
+// IsInvalid reports whether err is anything other than a *DataError with only warnings
+func IsInvalid(err error) bool {
+	var dataErr *DataError
+	if errors.As(err, &dataErr) {
+		return dataErr.Invalid()
+	}
+	return err != nil
+}
+
+// add records a problem for the given line and column
+func (e *DataError) add(line int, column string, format string, args ...interface{}) {
+	e.Rows = append(e.Rows, RowError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// warn records a warning for the given line and column
+func (e *DataError) warn(line int, column string, format string, args ...interface{}) {
+	e.Warnings = append(e.Warnings, RowError{Line: line, Column: column, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns nil when no problems or warnings were recorded, so callers don't get a typed nil.
+// Problems are sorted by line so they read in file order.
+func (e *DataError) err() error {
+	if len(e.Rows) == 0 && len(e.Warnings) == 0 {
+		return nil
+	}
+	sort.SliceStable(e.Rows, func(i, j int) bool { return e.Rows[i].Line < e.Rows[j].Line })
+	sort.SliceStable(e.Warnings, func(i, j int) bool { return e.Warnings[i].Line < e.Warnings[j].Line })
+	return e
+}
+
+// csvRow is a record of a csvTable together with its line in the file
+type csvRow struct {
+	line   int
+	fields []string
+}
+
+// csvTable is a CSV file whose columns are addressed by their header names
+type csvTable struct {
+	columns map[string]int
+	rows    []csvRow
+}
+
+// get returns the trimmed value of the named column, or "" if the table doesn't have it
+func (t *csvTable) get(row csvRow, column string) string {
+	i, ok := t.columns[column]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(row.fields[i])
+}
+
+// readTable reads CSV data, maps the header row and checks that every row has the right width.
+// Malformed rows are reported to errs and left out of the table.
+func readTable(data string, required []string, errs *DataError) *csvTable {
+	r := csv.NewReader(strings.NewReader(data))
+	r.FieldsPerRecord = -1 // Column count is checked against the header below
+
+	var table *csvTable
+	width := 0
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var pe *csv.ParseError
+			if errors.As(err, &pe) {
+				errs.add(pe.StartLine, "", "%v", pe.Err)
+				continue
+			}
+			errs.add(0, "", "failed to read CSV: %v", err)
+			break
+		}
+		line, _ := r.FieldPos(0)
+
+		// The first record is the header
+		if table == nil {
+			table = &csvTable{columns: make(map[string]int)}
+			width = len(record)
+			duplicate := false
+			for i, name := range record {
+				name = strings.ToLower(strings.TrimSpace(name))
+				if first, dup := table.columns[name]; dup {
+					errs.add(line, name, "duplicate column (columns %d and %d)", first+1, i+1)
+					duplicate = true
+					continue
+				}
+				table.columns[name] = i
+			}
+			// Rows can't be read reliably when two columns share a name, so the file is rejected
+			if duplicate {
+				return nil
+			}
+			for _, name := range required {
+				if _, ok := table.columns[name]; !ok {
+					errs.add(line, "", "missing required column %q", name)
+				}
+			}
+			continue
+		}
+
+		if len(record) != width {
+			errs.add(line, "", "expected %d columns, got %d", width, len(record))
+			continue
+		}
+		table.rows = append(table.rows, csvRow{line: line, fields: record})
+	}
+
+	if table == nil {
+		errs.add(0, "", "empty file: missing header row")
+		return nil
+	}
+	for _, name := range required {
+		if _, ok := table.columns[name]; !ok {
+			return nil
+		}
+	}
+	return table
+}
+
+// parseInt parses an integer column, recording a problem if it is malformed or outside [lo, hi]
+func parseInt(t *csvTable, row csvRow, column string, lo, hi int, optional bool, errs *DataError) int {
+	value := t.get(row, column)
+	if value == "" {
+		if !optional {
+			errs.add(row.line, column, "missing value")
+		}
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		errs.add(row.line, column, "%q is not an integer", value)
+		return 0
+	}
+	if n < lo || n > hi {
+		errs.add(row.line, column, "%d is out of range [%d, %d]", n, lo, hi)
+	}
+	return n
+}
+
+// parseOptional parses a nullable float column, recording a problem if it is malformed or not positive when required
+func parseOptional(t *csvTable, row csvRow, column string, positive bool, errs *DataError) Optional {
+	value := t.get(row, column)
+	if value == "" {
+		return Optional{}
+	}
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		errs.add(row.line, column, "%q is not a number", value)
+		return Optional{}
+	}
+	if positive && f <= 0 {
+		errs.add(row.line, column, "value must be positive, got %g", f)
+	}
+	return Optional{Value: f, Valid: true}
+}
+
+// parseStates parses a list of signed integers separated by semicolons (e.g. "-1;+1;+5")
+func parseStates(t *csvTable, row csvRow, column string, errs *DataError) []int {
+	var states []int
+	for _, field := range strings.Split(t.get(row, column), ";") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil {
+			errs.add(row.line, column, "%q is not an integer", field)
+			continue
+		}
+		states = append(states, n)
+	}
+	return states
+}
+
+// parseIonicRadii parses charge:radius pairs separated by semicolons (e.g. "+2:78;+3:64.5")
+func parseIonicRadii(t *csvTable, row csvRow, column string, errs *DataError) map[int]float64 {
+	value := t.get(row, column)
+	if value == "" {
+		return nil
+	}
+	radii := make(map[int]float64)
+	for _, field := range strings.Split(value, ";") {
+		charge, radius, ok := strings.Cut(strings.TrimSpace(field), ":")
+		if !ok {
+			errs.add(row.line, column, "%q is not a charge:radius pair", field)
+			continue
+		}
+		c, err1 := strconv.Atoi(charge)
+		r, err2 := strconv.ParseFloat(radius, 64)
+		if err1 != nil || err2 != nil || c == 0 || r <= 0 {
+			errs.add(row.line, column, "%q is not a charge:radius pair", field)
+			continue
+		}
+		radii[c] = r
+	}
+	return radii
+}
+
+// isSymbol reports whether s looks like an element symbol (e.g. "H", "Fe", "Uue")
+func isSymbol(s string) bool {
+	if len(s) == 0 || len(s) > 3 || s[0] < 'A' || s[0] > 'Z' {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if s[i] < 'a' || s[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// ReadElements parses and validates element CSV data without touching ElementTable.
+// Valid rows are always returned; if any row has problems the error is a *DataError listing all of them.
+func ReadElements(data string) ([]Element, error) {
+	errs := &DataError{}
+	table := readTable(data, []string{"symbol", "number", "amu"}, errs)
+	if table == nil {
+		return nil, errs.err()
+	}
+
+	var elements []Element
+	symbols := make(map[string]int)
+	numbers := make(map[int]int)
+	for _, row := range table.rows {
+		problems := len(errs.Rows)
+
+		symbol := table.get(row, "symbol")
+		if !isSymbol(symbol) {
+			errs.add(row.line, "symbol", "%q is not a valid element symbol", symbol)
+		} else if first, dup := symbols[symbol]; dup {
+			errs.add(row.line, "symbol", "duplicate symbol %s (first defined on line %d)", symbol, first)
+		} else {
+			symbols[symbol] = row.line
+		}
+
+		number := parseInt(table, row, "number", 1, 200, false, errs)
+		if first, dup := numbers[number]; dup && number > 0 {
+			errs.add(row.line, "number", "duplicate atomic number %d (first defined on line %d)", number, first)
+		} else if number > 0 {
+			numbers[number] = row.line
+		}
+
+		amu, err := strconv.ParseFloat(table.get(row, "amu"), 64)
+		if err != nil {
+			errs.add(row.line, "amu", "%q is not a number", table.get(row, "amu"))
+		} else if amu <= 0 {
+			errs.add(row.line, "amu", "mass must be positive, got %g", amu)
+		}
+
+		element := Element{
+			Symbol:   symbol,
+			Category: table.get(row, "category"),
+			Number:   number,
+			Group:    parseInt(table, row, "group", 0, 18, true, errs),
+			Amu:      amu,
+			Fact:     table.get(row, "fact"),
+			Period:   parseInt(table, row, "period", 1, 9, true, errs), // 8 and 9 are the f-block rows
+			Phase:    table.get(row, "phase"),
+			Name:     table.get(row, "name"),
+			Colour:   table.get(row, "colour"),
+
+			Electronegativity: parseOptional(table, row, "electronegativity", true, errs),
+			CovalentRadius:    parseOptional(table, row, "covalent_radius", true, errs),
+			AtomicRadius:      parseOptional(table, row, "atomic_radius", true, errs),
+			IonicRadii:        parseIonicRadii(table, row, "ionic_radii", errs),
+			IonizationEnergy: [3]Optional{
+				parseOptional(table, row, "ie1", true, errs),
+				parseOptional(table, row, "ie2", true, errs),
+				parseOptional(table, row, "ie3", true, errs),
+			},
+			ElectronAffinity: parseOptional(table, row, "electron_affinity", false, errs),
+			Density:          parseOptional(table, row, "density", true, errs),
+			MeltingPoint:     parseOptional(table, row, "melting_point", true, errs),
+			BoilingPoint:     parseOptional(table, row, "boiling_point", true, errs),
+			OxidationStates:  parseStates(table, row, "oxidation_states", errs),
+			Discovered:       parseOptional(table, row, "discovered", false, errs),
+		}
+
+		if len(errs.Rows) == problems {
+			elements = append(elements, element)
+		}
+	}
+
+	return elements, errs.err()
+}
+
+// LoadElements loads the CSV data of elements into the ElementTable map.
+// Nothing is loaded if the data has any problems.
+func LoadElements(data string) error {
+	elements, err := ReadElements(data)
+	if err != nil {
+		return err
+	}
+	MergeElements(elements)
+	return nil
+}
+
+// MergeElements adds elements to ElementTable, replacing any entry with the same symbol or atomic number.
+// It returns the symbols of the replaced entries.
+func MergeElements(elements []Element) []string {
+	var overridden []string
+	for _, element := range elements {
+		for symbol, old := range ElementTable {
+			if symbol == element.Symbol {
+				overridden = append(overridden, symbol)
+			} else if old.Number == element.Number {
+				overridden = append(overridden, fmt.Sprintf("%s (replaced by %s)", symbol, element.Symbol))
+				delete(ElementTable, symbol)
+			}
+		}
+		ElementTable[element.Symbol] = element
+	}
+	return overridden
+}
+
+// ReadMolecules parses and validates molecule CSV data without touching CompoundTable.
+// Formulas are resolved against ElementTable, so elements must be loaded first. A formula defined twice
+// keeps its last row, and the earlier one is reported as a warning.
+func ReadMolecules(data string) ([]Compound, error) {
+	errs := &DataError{}
+	table := readTable(data, []string{"formula", "name"}, errs)
+	if table == nil {
+		return nil, errs.err()
+	}
+
+	// formulaRow is where a formula was last defined, by its index in compounds and its line,
+	// and nameRow the formula and line a name was last given to
+	type formulaRow struct{ index, line int }
+	type nameRow struct {
+		key  string
+		line int
+	}
+	var compounds []Compound
+	formulas := make(map[string]formulaRow)
+	names := make(map[string]nameRow)
+	replaced := make(map[int]bool)
+	for _, row := range table.rows {
+		formula := table.get(row, "formula")
+		if formula == "" {
+			errs.add(row.line, "formula", "missing value")
+			continue
+		}
+
+		p := NewParser(formula)
+		compound, err := p.ParseCompound()
+		if err != nil {
+			errs.add(row.line, "formula", "%s: %v", formula, err)
+			continue
+		}
+		if p.token.typ != TOKEN_END {
+			errs.add(row.line, "formula", "%s: unexpected %q at position %d", formula, p.token.value, p.pos)
+			continue
+		}
+
+		unknown := false
+		for _, el := range compound.ToMolecule().Elements {
+			if _, ok := lookupElement(el.Symbol); !ok {
+				errs.add(row.line, "formula", "%s: unknown element %s", formula, el.Symbol)
+				unknown = true
+				break
+			}
+		}
+		if unknown {
+			continue
+		}
+
+		// A formula or name given twice keeps its last row, as a later layer replaces an earlier one
+		compound.Name = table.get(row, "name")
+		compound.State = table.get(row, "state")
+		key := compound.ToString()
+		if earlier, dup := formulas[key]; dup {
+			errs.warn(row.line, "formula", "duplicate formula %s replaces line %d", key, earlier.line)
+			replaced[earlier.index] = true
+		}
+		formulas[key] = formulaRow{len(compounds), row.line}
+		if name := strings.ToLower(compound.Name); name != "" {
+			if earlier, dup := names[name]; dup && earlier.key != key {
+				errs.warn(row.line, "name", "%q also names %s on line %d and now looks up %s", compound.Name, earlier.key, earlier.line, key)
+			}
+			names[name] = nameRow{key, row.line}
+		}
+		compounds = append(compounds, compound)
+	}
+
+	// Keep the surviving rows in file order, so that the last row with a name is merged last
+	kept := compounds[:0]
+	for i, compound := range compounds {
+		if !replaced[i] {
+			kept = append(kept, compound)
+		}
+	}
+	return kept, errs.err()
+}
+
+// LoadMolecules loads the CSV data of molecules into the CompoundTable map.
+// Nothing is loaded if the data has any problems; warnings alone don't stop it.
+func LoadMolecules(data string) error {
+	compounds, err := ReadMolecules(data)
+	if IsInvalid(err) {
+		return err
+	}
+	MergeMolecules(compounds)
+	return nil
+}
+
+// MergeMolecules adds compounds to CompoundTable, replacing any entry with the same formula, and looks up each
+// name by the last compound given it. It returns the formulas of the replaced entries, and the names that
+// looked up another formula before the merge.
+func MergeMolecules(compounds []Compound) []string {
+	var overridden []string
+	merged := make(map[string]bool)
+	for _, compound := range compounds {
+		key := compound.ToString()
+		if _, exists := CompoundTable[key]; exists {
+			overridden = append(overridden, key)
+		}
+		CompoundTable[key] = compound
+		if compound.Name == "" {
+			continue
+		}
+		name := strings.ToLower(compound.Name)
+		if old, exists := compoundNames[name]; exists && old != key && !merged[name] {
+			overridden = append(overridden, fmt.Sprintf("name %q (was %s, now %s)", compound.Name, old, key))
+		}
+		compoundNames[name] = key
+		merged[name] = true
+	}
+	return overridden
+}
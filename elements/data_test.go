@@ -0,0 +1,63 @@
+package elements
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReadTableDuplicateColumn(t *testing.T) {
+	errs := &DataError{}
+	table := readTable("formula,name,name\nH2O,water,\n", []string{"formula", "name"}, errs)
+	if table != nil {
+		t.Fatal("readTable accepted a header with a duplicate column")
+	}
+	if len(errs.Rows) != 1 || !strings.Contains(errs.Rows[0].Message, "duplicate column") {
+		t.Errorf("readTable problems = %v, want one duplicate column", errs.Rows)
+	}
+}
+
+func TestReadMoleculesDuplicates(t *testing.T) {
+	loadTestElements(t)
+	compounds, err := ReadMolecules("formula,name,state\nCO2,first,g\nH2O,water,l\nCO2,carbon dioxide,g\n")
+	var dataErr *DataError
+	if !errors.As(err, &dataErr) || dataErr.Invalid() || len(dataErr.Warnings) != 1 {
+		t.Fatalf("ReadMolecules error = %v, want one warning", err)
+	}
+	if len(compounds) != 2 || compounds[1].Name != "carbon dioxide" {
+		t.Errorf("ReadMolecules = %v, want the last CO2 row to win", compounds)
+	}
+}
+
+func TestReadMoleculesSharedName(t *testing.T) {
+	loadTestElements(t)
+	compounds, err := ReadMolecules("formula,name,state\nClNa,sodium chloride,s\nNaCl,Sodium Chloride,s\n")
+	var dataErr *DataError
+	if !errors.As(err, &dataErr) || dataErr.Invalid() || len(dataErr.Warnings) != 1 {
+		t.Fatalf("ReadMolecules error = %v, want one warning", err)
+	}
+	MergeMolecules(compounds)
+	compound, err := ParseFormula("sodium chloride")
+	if err != nil || compound.ToString() != "NaCl" {
+		t.Errorf("ParseFormula(\"sodium chloride\") = %s, %v, want the last row, NaCl", compound.ToString(), err)
+	}
+
+	overridden := MergeMolecules([]Compound{{Molecules: []Molecule{{Elements: []Element{ElementTable["Na"], ElementTable["Cl"], ElementTable["Na"], ElementTable["Cl"]}}}, Name: "sodium chloride"}})
+	if len(overridden) != 1 || !strings.Contains(overridden[0], "sodium chloride") {
+		t.Errorf("MergeMolecules overrides = %v, want the name sodium chloride", overridden)
+	}
+}
+
+func TestReadMoleculesHydrogenIsotopes(t *testing.T) {
+	loadTestElements(t)
+	compounds, err := ReadMolecules("formula,name,state\nD2O,deuterium oxide,l\nHD,hydrogen deuteride,g\nT2O,tritium oxide,\n2H2O,deuterium oxide,\n")
+	if !IsInvalid(err) {
+		t.Fatal("ReadMolecules accepted 2H2O")
+	}
+	if len(compounds) != 3 {
+		t.Fatalf("ReadMolecules returned %d molecules, want 3", len(compounds))
+	}
+	if mass := compounds[0].GetMass(); mass < 20.02 || mass > 20.03 {
+		t.Errorf("mass of D2O = %g, want 20.027", mass)
+	}
+}
@@ -0,0 +1,84 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Year is the Julian year in seconds, the year used for half-lives
+const Year = 365.25 * 86400
+
+// durationUnits maps the units accepted by ParseDuration to seconds
+var durationUnits = map[string]float64{
+	"ns": 1e-9,
+	"us": 1e-6, "µs": 1e-6,
+	"ms": 1e-3,
+	"s":  1, "sec": 1,
+	"min": 60,
+	"h":   3600, "hr": 3600,
+	"d": 86400, "day": 86400, "days": 86400,
+	"y": Year, "yr": Year, "a": Year, "year": Year, "years": Year,
+	"ky": 1e3 * Year, "kyr": 1e3 * Year,
+	"my": 1e6 * Year, "myr": 1e6 * Year,
+	"gy": 1e9 * Year, "gyr": 1e9 * Year,
+}
+
+// ParseDuration parses a number of seconds, minutes, hours, days or years with its unit (e.g. "5730y",
+// "3.82 d", "1e9 y", "164 µs" or "4.5 Gyr") and returns it in seconds
+func ParseDuration(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	i := strings.LastIndexAny(s, "0123456789.") + 1
+	number, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a duration, write it like 5730y or 3.8 d", s)
+	}
+	if unit == "" {
+		return 0, fmt.Errorf("%q has no unit, use one of s, min, h, d or y", s)
+	}
+	scale, ok := durationUnits[unit]
+	if !ok {
+		return 0, fmt.Errorf("unknown unit %q in %q, use one of ns, µs, ms, s, min, h, d, y, ky, My or Gy", s[i:], s)
+	}
+	return value * scale, nil
+}
+
+// FormatDuration writes a number of seconds in the largest unit it is at least one of (e.g. "5730 y" or "3.824 d")
+func FormatDuration(seconds float64) string {
+	units := []struct {
+		name  string
+		scale float64
+	}{{"y", Year}, {"d", 86400}, {"h", 3600}, {"min", 60}, {"s", 1}, {"ms", 1e-3}, {"µs", 1e-6}, {"ns", 1e-9}}
+	for _, unit := range units {
+		if math.Abs(seconds) >= unit.scale || unit.name == "ns" {
+			return FormatNumber(seconds/unit.scale) + " " + unit.name
+		}
+	}
+	return "0 s"
+}
+
+// FormatNumber writes a number to 4 significant figures, in powers of ten once it is a million or more
+// or less than a thousandth (e.g. "5730", "4.468e9" or "2.5e-5")
+func FormatNumber(x float64) string {
+	s := strconv.FormatFloat(x, 'g', 4, 64)
+	if a := math.Abs(x); a != 0 && (a >= 1e6 || a < 1e-3) {
+		s = strconv.FormatFloat(x, 'e', 3, 64)
+	}
+	// Drop trailing zeros of the mantissa and the exponent's sign and padding
+	mantissa, exponent, found := strings.Cut(s, "e")
+	if strings.Contains(mantissa, ".") {
+		mantissa = strings.TrimRight(strings.TrimRight(mantissa, "0"), ".")
+	}
+	if !found {
+		return mantissa
+	}
+	exponent = strings.TrimPrefix(exponent, "+")
+	negative := strings.HasPrefix(exponent, "-")
+	exponent = strings.TrimLeft(strings.TrimPrefix(exponent, "-"), "0")
+	if negative {
+		exponent = "-" + exponent
+	}
+	return mantissa + "e" + exponent
+}
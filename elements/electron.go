@@ -2,6 +2,7 @@ package elements
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -25,52 +26,137 @@ func (ec ElectronConfiguration) ToString() string {
 	return strings.TrimSpace(sb.String())
 }
 
+// nobleGasCores are the atomic numbers and symbols of the noble gases, lightest first
+var nobleGasCores = []struct {
+	number int
+	symbol string
+}{{2, "He"}, {10, "Ne"}, {18, "Ar"}, {36, "Kr"}, {54, "Xe"}, {86, "Rn"}, {118, "Og"}}
+
+// Electrons returns the total number of electrons in the configuration
+func (ec ElectronConfiguration) Electrons() int {
+	total := 0
+	for _, subshell := range ec.Subshells {
+		total += subshell.Electrons
+	}
+	return total
+}
+
+// Abbreviated returns the configuration with the largest complete noble-gas core replaced by its symbol (e.g., "[Ar] 4s2 3d6").
+// The remaining subshells keep their order.
+func (ec ElectronConfiguration) Abbreviated() string {
+	for i := len(nobleGasCores) - 1; i >= 0; i-- {
+		core := nobleGasCores[i]
+		if core.number >= ec.Electrons() {
+			continue
+		}
+		coreSubshells := make(map[string]bool)
+		complete := true
+		for _, subshell := range GenerateElectronConfiguration(core.number).Subshells {
+			coreSubshells[subshell.Orbital] = true
+			if ec.count(subshell.Orbital) != subshell.Electrons {
+				complete = false
+				break
+			}
+		}
+		if !complete {
+			continue
+		}
+
+		outer := ElectronConfiguration{}
+		for _, subshell := range ec.Subshells {
+			if !coreSubshells[subshell.Orbital] {
+				outer.Subshells = append(outer.Subshells, subshell)
+			}
+		}
+		return strings.TrimSpace("[" + core.symbol + "] " + outer.ToString())
+	}
+	return ec.ToString()
+}
+
+// count returns the number of electrons in an orbital of the configuration
+func (ec ElectronConfiguration) count(orbital string) int {
+	for _, subshell := range ec.Subshells {
+		if subshell.Orbital == orbital {
+			return subshell.Electrons
+		}
+	}
+	return 0
+}
+
+// index returns the position of an orbital in the configuration, or -1 if it isn't listed
+func (ec ElectronConfiguration) index(orbital string) int {
+	for i, subshell := range ec.Subshells {
+		if subshell.Orbital == orbital {
+			return i
+		}
+	}
+	return -1
+}
+
+// ByShell returns the configuration with its subshells ordered by n and then l, instead of filling order (e.g., "3d6 4s2")
+func (ec ElectronConfiguration) ByShell() ElectronConfiguration {
+	subshells := append([]Subshell(nil), ec.Subshells...)
+	sort.SliceStable(subshells, func(i, j int) bool {
+		ni, nj := getPrincipalQuantumNumber(subshells[i].Orbital), getPrincipalQuantumNumber(subshells[j].Orbital)
+		if ni != nj {
+			return ni < nj
+		}
+		return getAzimuthalQuantumNumber(subshells[i].Orbital) < getAzimuthalQuantumNumber(subshells[j].Orbital)
+	})
+	return ElectronConfiguration{Subshells: subshells}
+}
+
+// Shells returns the number of electrons in each shell from n = 1 outwards (e.g., [2 8 14 2] for iron)
+func (ec ElectronConfiguration) Shells() []int {
+	var shells []int
+	for _, subshell := range ec.Subshells {
+		n := getPrincipalQuantumNumber(subshell.Orbital)
+		for len(shells) < n {
+			shells = append(shells, 0)
+		}
+		shells[n-1] += subshell.Electrons
+	}
+	return shells
+}
+
+// exceptionConfigurations are the measured ground states of the elements that break the filling order.
+// Elements past Lr are only predicted and follow the filling order, like the rest of the table.
 var exceptionConfigurations = map[int]string{
-	// Transition Metals
-	24: "1s2 2s2 2p6 3s2 3p6 4s1 3d5",  // Chromium (Cr)
+	// Transition metals
+	24: "1s2 2s2 2p6 3s2 3p6 4s1 3d5", // Chromium (Cr)
 	29: "1s2 2s2 2p6 3s2 3p6 4s1 3d10", // Copper (Cu)
-	39: "1s2 2s2 2p6 3s2 3p6 4s2 3d5",  // Yttrium (Y)
-	40: "1s2 2s2 2p6 3s2 3p6 4s2 3d10", // Zirconium (Zr)
-	41: "1s2 2s2 2p6 3s2 3p6 4s2 3d5",  // Niobium (Nb)
-	42: "1s2 2s2 2p6 3s2 3p6 4s2 3d10", // Molybdenum (Mo)
-	43: "1s2 2s2 2p6 3s2 3p6 4s2 3d5",  // Technetium (Tc)
-	44: "1s2 2s2 2p6 3s2 3p6 4s2 3d10", // Ruthenium (Ru)
-	45: "1s2 2s2 2p6 3s2 3p6 4s2 3d5",  // Rhodium (Rh)
-	46: "1s2 2s2 2p6 3s2 3p6 4s2 3d10", // Palladium (Pd)
-	47: "1s2 2s2 2p6 3s2 3p6 4s2 3d10", // Silver (Ag)
-	48: "1s2 2s2 2p6 3s2 3p6 4s2 3d10", // Cadmium (Cd)
-	57: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Lanthanum (La)
-	58: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Cerium (Ce)
-	59: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Praseodymium (Pr)
-	60: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Neodymium (Nd)
-	61: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Promethium (Pm)
-	62: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Samarium (Sm)
-	63: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Europium (Eu)
-	64: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Gadolinium (Gd)
-	65: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Terbium (Tb)
-	66: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Dysprosium (Dy)
-	67: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Holmium (Ho)
-	68: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Erbium (Er)
-	69: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Thulium (Tm)
-	70: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Ytterbium (Yb)
-	71: "1s2 2s2 2p6 3s2 3p6 4s2 4f14 5d10 6s2", // Lutetium (Lu)
-	90: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Actinium (Ac)
-	91: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Protactinium (Pa)
-	92: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Uranium (U)
-	93: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Neptunium (Np)
-	94: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Plutonium (Pu)
-	95: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Americium (Am)
-	96: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Curium (Cm)
-	97: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Berkelium (Bk)
-	98: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Californium (Cf)
-	99: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Einsteinium (Es)
-	100: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Fermium (Fm)
-	101: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Mendelevium (Md)
-	102: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Nobelium (No)
-	103: "1s2 2s2 2p6 3s2 3p6 4s2 5f14 6d10 7s2", // Lawrencium (Lr)
+	41: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s1 4d4", // Niobium (Nb)
+	42: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s1 4d5", // Molybdenum (Mo)
+	44: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s1 4d7", // Ruthenium (Ru)
+	45: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s1 4d8", // Rhodium (Rh)
+	46: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 4d10", // Palladium (Pd)
+	47: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s1 4d10", // Silver (Ag)
+	// Lanthanides
+	57: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 5d1", // Lanthanum (La)
+	58: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f1 5d1", // Cerium (Ce)
+	64: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f7 5d1", // Gadolinium (Gd)
+	// Period 6 transition metals
+	78: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s1 4f14 5d9", // Platinum (Pt)
+	79: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s1 4f14 5d10", // Gold (Au)
+	// Actinides
+	89: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6 7s2 6d1", // Actinium (Ac)
+	90: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6 7s2 6d2", // Thorium (Th)
+	91: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6 7s2 5f2 6d1", // Protactinium (Pa)
+	92: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6 7s2 5f3 6d1", // Uranium (U)
+	93: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6 7s2 5f4 6d1", // Neptunium (Np)
+	96: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6 7s2 5f7 6d1", // Curium (Cm)
+	103: "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6 7s2 5f14 7p1", // Lawrencium (Lr)
 }
 
 
+// fillingOrder lists the orbitals in the order of filling
+var fillingOrder = []string{"1s", "2s", "2p", "3s", "3p", "4s", "3d", "4p", "5s", "4d", "5p", "6s", "4f", "5d", "6p", "7s", "5f", "6d", "7p"}
+
+// subshellCapacity is the maximum number of electrons each subshell can hold
+var subshellCapacity = map[string]int{
+	"s": 2, "p": 6, "d": 10, "f": 14,
+}
+
 // GenerateElectronConfiguration generates the electron configuration for an element based on its atomic number
 func GenerateElectronConfiguration(atomicNumber int) ElectronConfiguration {
 	// Check if this element has an exception
@@ -78,20 +164,13 @@ func GenerateElectronConfiguration(atomicNumber int) ElectronConfiguration {
 		return parseConfigurationString(exceptionConfig)
 	}
 
-	// The list of orbitals in the order of filling
-	orbitals := []string{"1s", "2s", "2p", "3s", "3p", "4s", "3d", "4p", "5s", "4d", "5p", "6s", "4f", "5d", "6p", "7s", "5f", "6d", "7p"}
-	// Maximum number of electrons each subshell can hold
-	maxElectrons := map[string]int{
-		"s": 2, "p": 6, "d": 10, "f": 14,
-	}
-
 	configuration := ElectronConfiguration{}
 	electronsRemaining := atomicNumber
 
-	for _, orbital := range orbitals {
+	for _, orbital := range fillingOrder {
 		// Determine the type of subshell (s, p, d, f) and its max electrons
 		subshellType := string(orbital[len(orbital)-1:])
-		maxInSubshell := maxElectrons[subshellType]
+		maxInSubshell := subshellCapacity[subshellType]
 
 		// Determine how many electrons to fill in this subshell
 		electronsInSubshell := min(electronsRemaining, maxInSubshell)
@@ -122,23 +201,57 @@ func min(a, b int) int {
 	return b
 }
 
-// parseConfigurationString parses an electron configuration string (e.g., "1s2 2s2 2p6") into ElectronConfiguration
+// parseConfigurationString parses an electron configuration string known to be valid (e.g., "1s2 2s2 2p6")
 func parseConfigurationString(configStr string) ElectronConfiguration {
-	configuration := ElectronConfiguration{}
-	parts := strings.Fields(configStr) // Split the string into individual subshells (e.g., "1s2", "2s2")
+	configuration, _ := ParseElectronConfiguration(configStr)
+	return configuration
+}
 
-	for _, part := range parts {
-		// Extract the orbital (e.g., "1s") and electron count (e.g., "2")
-		var orbital string
-		var electrons int
-		fmt.Sscanf(part, "%2s%d", &orbital, &electrons) // Parse the subshell and electron count
+// Occupancy returns the number of electrons in each orbital of the subshell, filled by Hund's rule:
+// every orbital takes one spin-up electron before any takes a second, spin-down one (Pauli principle).
+// Orbitals are listed from m = +l down to m = -l.
+func (s Subshell) Occupancy() []int {
+	orbitals := make([]int, 2*getAzimuthalQuantumNumber(s.Orbital)+1)
+	for i := 0; i < s.Electrons && i < 2*len(orbitals); i++ {
+		orbitals[i%len(orbitals)]++
+	}
+	return orbitals
+}
 
-		configuration.Subshells = append(configuration.Subshells, Subshell{
-			Orbital: orbital,
-			Electrons: electrons,
-		})
+// UnpairedElectrons returns the number of orbitals holding a single electron
+func (ec ElectronConfiguration) UnpairedElectrons() int {
+	unpaired := 0
+	for _, subshell := range ec.Subshells {
+		for _, electrons := range subshell.Occupancy() {
+			if electrons == 1 {
+				unpaired++
+			}
+		}
 	}
+	return unpaired
+}
 
-	return configuration
+// Paramagnetic reports whether the configuration has unpaired electrons, otherwise it is diamagnetic
+func (ec ElectronConfiguration) Paramagnetic() bool {
+	return ec.UnpairedElectrons() > 0
 }
 
+// OrbitalDiagram draws each subshell on its own line as a row of orbital boxes with ↑/↓ arrows (e.g., "2p [↑↓][↑ ][↑ ]")
+func (ec ElectronConfiguration) OrbitalDiagram() string {
+	var sb strings.Builder
+	for _, subshell := range ec.Subshells {
+		sb.WriteString(fmt.Sprintf("%-3s ", subshell.Orbital))
+		for _, electrons := range subshell.Occupancy() {
+			switch electrons {
+			case 2:
+				sb.WriteString("[↑↓]")
+			case 1:
+				sb.WriteString("[↑ ]")
+			default:
+				sb.WriteString("[  ]")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
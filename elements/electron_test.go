@@ -0,0 +1,156 @@
+package elements
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestElectronCount(t *testing.T) {
+	for z := 1; z <= 118; z++ {
+		if got := GenerateElectronConfiguration(z).Electrons(); got != z {
+			t.Errorf("GenerateElectronConfiguration(%d) has %d electrons", z, got)
+		}
+	}
+}
+
+// referenceCores spell out the noble-gas cores used in referenceConfigurations
+var referenceCores = strings.NewReplacer(
+	"[He]", "1s2",
+	"[Ne]", "1s2 2s2 2p6",
+	"[Ar]", "1s2 2s2 2p6 3s2 3p6",
+	"[Kr]", "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6",
+	"[Xe]", "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6",
+	"[Rn]", "1s2 2s2 2p6 3s2 3p6 4s2 3d10 4p6 5s2 4d10 5p6 6s2 4f14 5d10 6p6",
+)
+
+// referenceConfigurations are the ground-state configurations of every element, measured up to Lr and in filling
+// order past it, with subshells listed as GenerateElectronConfiguration lists them
+var referenceConfigurations = map[int]string{
+	1:   "1s1",                    // H
+	2:   "1s2",                    // He
+	3:   "[He] 2s1",               // Li
+	4:   "[He] 2s2",               // Be
+	5:   "[He] 2s2 2p1",           // B
+	6:   "[He] 2s2 2p2",           // C
+	7:   "[He] 2s2 2p3",           // N
+	8:   "[He] 2s2 2p4",           // O
+	9:   "[He] 2s2 2p5",           // F
+	10:  "[He] 2s2 2p6",           // Ne
+	11:  "[Ne] 3s1",               // Na
+	12:  "[Ne] 3s2",               // Mg
+	13:  "[Ne] 3s2 3p1",           // Al
+	14:  "[Ne] 3s2 3p2",           // Si
+	15:  "[Ne] 3s2 3p3",           // P
+	16:  "[Ne] 3s2 3p4",           // S
+	17:  "[Ne] 3s2 3p5",           // Cl
+	18:  "[Ne] 3s2 3p6",           // Ar
+	19:  "[Ar] 4s1",               // K
+	20:  "[Ar] 4s2",               // Ca
+	21:  "[Ar] 4s2 3d1",           // Sc
+	22:  "[Ar] 4s2 3d2",           // Ti
+	23:  "[Ar] 4s2 3d3",           // V
+	24:  "[Ar] 4s1 3d5",           // Cr
+	25:  "[Ar] 4s2 3d5",           // Mn
+	26:  "[Ar] 4s2 3d6",           // Fe
+	27:  "[Ar] 4s2 3d7",           // Co
+	28:  "[Ar] 4s2 3d8",           // Ni
+	29:  "[Ar] 4s1 3d10",          // Cu
+	30:  "[Ar] 4s2 3d10",          // Zn
+	31:  "[Ar] 4s2 3d10 4p1",      // Ga
+	32:  "[Ar] 4s2 3d10 4p2",      // Ge
+	33:  "[Ar] 4s2 3d10 4p3",      // As
+	34:  "[Ar] 4s2 3d10 4p4",      // Se
+	35:  "[Ar] 4s2 3d10 4p5",      // Br
+	36:  "[Ar] 4s2 3d10 4p6",      // Kr
+	37:  "[Kr] 5s1",               // Rb
+	38:  "[Kr] 5s2",               // Sr
+	39:  "[Kr] 5s2 4d1",           // Y
+	40:  "[Kr] 5s2 4d2",           // Zr
+	41:  "[Kr] 5s1 4d4",           // Nb
+	42:  "[Kr] 5s1 4d5",           // Mo
+	43:  "[Kr] 5s2 4d5",           // Tc
+	44:  "[Kr] 5s1 4d7",           // Ru
+	45:  "[Kr] 5s1 4d8",           // Rh
+	46:  "[Kr] 4d10",              // Pd
+	47:  "[Kr] 5s1 4d10",          // Ag
+	48:  "[Kr] 5s2 4d10",          // Cd
+	49:  "[Kr] 5s2 4d10 5p1",      // In
+	50:  "[Kr] 5s2 4d10 5p2",      // Sn
+	51:  "[Kr] 5s2 4d10 5p3",      // Sb
+	52:  "[Kr] 5s2 4d10 5p4",      // Te
+	53:  "[Kr] 5s2 4d10 5p5",      // I
+	54:  "[Kr] 5s2 4d10 5p6",      // Xe
+	55:  "[Xe] 6s1",               // Cs
+	56:  "[Xe] 6s2",               // Ba
+	57:  "[Xe] 6s2 5d1",           // La
+	58:  "[Xe] 6s2 4f1 5d1",       // Ce
+	59:  "[Xe] 6s2 4f3",           // Pr
+	60:  "[Xe] 6s2 4f4",           // Nd
+	61:  "[Xe] 6s2 4f5",           // Pm
+	62:  "[Xe] 6s2 4f6",           // Sm
+	63:  "[Xe] 6s2 4f7",           // Eu
+	64:  "[Xe] 6s2 4f7 5d1",       // Gd
+	65:  "[Xe] 6s2 4f9",           // Tb
+	66:  "[Xe] 6s2 4f10",          // Dy
+	67:  "[Xe] 6s2 4f11",          // Ho
+	68:  "[Xe] 6s2 4f12",          // Er
+	69:  "[Xe] 6s2 4f13",          // Tm
+	70:  "[Xe] 6s2 4f14",          // Yb
+	71:  "[Xe] 6s2 4f14 5d1",      // Lu
+	72:  "[Xe] 6s2 4f14 5d2",      // Hf
+	73:  "[Xe] 6s2 4f14 5d3",      // Ta
+	74:  "[Xe] 6s2 4f14 5d4",      // W
+	75:  "[Xe] 6s2 4f14 5d5",      // Re
+	76:  "[Xe] 6s2 4f14 5d6",      // Os
+	77:  "[Xe] 6s2 4f14 5d7",      // Ir
+	78:  "[Xe] 6s1 4f14 5d9",      // Pt
+	79:  "[Xe] 6s1 4f14 5d10",     // Au
+	80:  "[Xe] 6s2 4f14 5d10",     // Hg
+	81:  "[Xe] 6s2 4f14 5d10 6p1", // Tl
+	82:  "[Xe] 6s2 4f14 5d10 6p2", // Pb
+	83:  "[Xe] 6s2 4f14 5d10 6p3", // Bi
+	84:  "[Xe] 6s2 4f14 5d10 6p4", // Po
+	85:  "[Xe] 6s2 4f14 5d10 6p5", // At
+	86:  "[Xe] 6s2 4f14 5d10 6p6", // Rn
+	87:  "[Rn] 7s1",               // Fr
+	88:  "[Rn] 7s2",               // Ra
+	89:  "[Rn] 7s2 6d1",           // Ac
+	90:  "[Rn] 7s2 6d2",           // Th
+	91:  "[Rn] 7s2 5f2 6d1",       // Pa
+	92:  "[Rn] 7s2 5f3 6d1",       // U
+	93:  "[Rn] 7s2 5f4 6d1",       // Np
+	94:  "[Rn] 7s2 5f6",           // Pu
+	95:  "[Rn] 7s2 5f7",           // Am
+	96:  "[Rn] 7s2 5f7 6d1",       // Cm
+	97:  "[Rn] 7s2 5f9",           // Bk
+	98:  "[Rn] 7s2 5f10",          // Cf
+	99:  "[Rn] 7s2 5f11",          // Es
+	100: "[Rn] 7s2 5f12",          // Fm
+	101: "[Rn] 7s2 5f13",          // Md
+	102: "[Rn] 7s2 5f14",          // No
+	103: "[Rn] 7s2 5f14 7p1",      // Lr
+	104: "[Rn] 7s2 5f14 6d2",      // Rf
+	105: "[Rn] 7s2 5f14 6d3",      // Db
+	106: "[Rn] 7s2 5f14 6d4",      // Sg
+	107: "[Rn] 7s2 5f14 6d5",      // Bh
+	108: "[Rn] 7s2 5f14 6d6",      // Hs
+	109: "[Rn] 7s2 5f14 6d7",      // Mt
+	110: "[Rn] 7s2 5f14 6d8",      // Ds
+	111: "[Rn] 7s2 5f14 6d9",      // Rg
+	112: "[Rn] 7s2 5f14 6d10",     // Cn
+	113: "[Rn] 7s2 5f14 6d10 7p1", // Nh
+	114: "[Rn] 7s2 5f14 6d10 7p2", // Fl
+	115: "[Rn] 7s2 5f14 6d10 7p3", // Mc
+	116: "[Rn] 7s2 5f14 6d10 7p4", // Lv
+	117: "[Rn] 7s2 5f14 6d10 7p5", // Ts
+	118: "[Rn] 7s2 5f14 6d10 7p6", // Og
+}
+
+func TestElectronConfigurations(t *testing.T) {
+	for z := 1; z <= 118; z++ {
+		want := referenceCores.Replace(referenceConfigurations[z])
+		if got := GenerateElectronConfiguration(z).ToString(); got != want {
+			t.Errorf("GenerateElectronConfiguration(%d) = %s, want %s", z, got, want)
+		}
+	}
+}
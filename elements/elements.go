@@ -1,12 +1,9 @@
 package elements
 
 import (
-	"encoding/csv"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
-	"github.com/mattn/go-colorable"
 )
 
 // Element represents a chemical element and its properties
@@ -22,6 +19,32 @@ type Element struct {
 	Name     string
 	Colour   string
 	Charge int
+
+	Electronegativity Optional        // Pauling scale
+	CovalentRadius    Optional        // pm
+	AtomicRadius      Optional        // pm, empirical
+	IonicRadii        map[int]float64 // pm for six-coordinate ions, keyed by charge
+	IonizationEnergy  [3]Optional     // kJ/mol, first through third
+	ElectronAffinity  Optional        // kJ/mol
+	Density           Optional        // g/cm³
+	MeltingPoint      Optional        // K
+	BoilingPoint      Optional        // K
+	OxidationStates   []int
+	Discovered        Optional // Year, missing for elements known since antiquity
+}
+
+// Optional is a numeric property that may be missing from the data
+type Optional struct {
+	Value float64
+	Valid bool
+}
+
+// ToString formats the value, or returns "-" if it is missing
+func (o Optional) ToString() string {
+	if !o.Valid {
+		return "-"
+	}
+	return strconv.FormatFloat(o.Value, 'f', -1, 64)
 }
 
 func (el Element) ToString() string {
@@ -39,12 +62,14 @@ func (el Element) ToString() string {
 
 
 
+// GetElectronConfiguration returns the ground-state configuration of the element, or of its ion if it is charged
 func (el Element) GetElectronConfiguration() ElectronConfiguration {
-	return GenerateElectronConfiguration(el.Number)
+	return IonConfiguration(el.Number, el.Charge)
 }
 
+// GetQuantumNumbers returns the quantum numbers of the electron at index i of the element or ion, counting from 0
 func (el Element) GetQuantumNumbers(i int) (QuantumNumbers, error) {
-	return GetQuantumNumbers(el.Number, i)
+	return electronQuantumNumbers(el.GetElectronConfiguration(), i)
 }
 
 // Molecule represents a parsed chemical formula
@@ -126,74 +151,35 @@ func (m Molecule) GetMass() float64 {
 var ElementTable = map[string]Element{}
 var CompoundTable = map[string]Compound{}
 
-// LoadElements loads the CSV data of elements into the ElementTable map
-func LoadElements(data string) error {
-	r := csv.NewReader(strings.NewReader(data))
-	records, err := r.ReadAll()
-	if err != nil {
-		return fmt.Errorf("failed to read CSV: %v", err)
-	}
-
-	for _, record := range records[1:] { // Skip header
-		number, _ := strconv.Atoi(record[2])
-		group, _ := strconv.Atoi(record[3])
-		amu, _ := strconv.ParseFloat(record[4], 64)
-		period, _ := strconv.Atoi(record[6])
-
-		element := Element{
-			Symbol:   record[0],
-			Category: record[1],
-			Number:   number,
-			Group:    group,
-			Amu:      amu,
-			Fact:     record[5],
-			Period:   period,
-			Phase:    record[7],
-			Name:     record[8],
-			Colour:   record[9],
-		}
-
-		ElementTable[element.Symbol] = element
-	}
-
-	return nil
+// hydrogenIsotopes are deuterium and tritium, which formulas may write as D and T
+var hydrogenIsotopes = map[string]struct {
+	name string
+	amu  float64
+}{
+	"D": {"Deuterium", 2.01410177812},
+	"T": {"Tritium", 3.0160492779},
 }
 
-
-// LoadMolecules loads the CSV data into a slice of Molecules
-func LoadMolecules(data string) error {
-	r := csv.NewReader(strings.NewReader(data))
-	records, err := r.ReadAll()
-	if err != nil {
-		return fmt.Errorf("failed to read CSV: %v", err)
+// lookupElement returns the element with the symbol from ElementTable, or hydrogen with the name and mass
+// of deuterium or tritium for D and T
+func lookupElement(symbol string) (Element, bool) {
+	if el, exists := ElementTable[symbol]; exists {
+		return el, true
 	}
-
-	for ri, record := range records[1:] { // Skip header
-		if len(record) < 3 {
-			return errors.New("invalid record: insufficient columns")
-		}
-
-		formula := record[0]
-		name := record[1]
-		state := record[2]
-
-		// Parse the formula to get elements
-		p := NewParser(formula)
-		compound, err := p.ParseCompound()
-		if err != nil {
-			return fmt.Errorf("%s:%d, %s\n", formula, ri+2, err.Error())
-		}
-		
-		// Set the Name and Charge
-		compound.Name = name
-		compound.State = state
-
-		CompoundTable[compound.ToString()] = compound
+	isotope, ok := hydrogenIsotopes[symbol]
+	if !ok {
+		return Element{}, false
 	}
-
-	return nil
+	el, exists := ElementTable["H"]
+	if !exists {
+		return Element{}, false
+	}
+	el.Symbol, el.Name, el.Amu = symbol, isotope.name, isotope.amu
+	return el, true
 }
 
+// compoundNames maps lower-cased compound names (including trade names) to their CompoundTable keys
+var compoundNames = map[string]string{}
 
 // Compound represents a chemical compound made up of multiple molecules or ions
 type Compound struct {
@@ -283,6 +269,18 @@ func (c Compound) GetCharge() int {
 	return charge
 }
 
+// isKnown reports whether every element in the compound is in ElementTable
+func (c Compound) isKnown() bool {
+	for _, mol := range c.Molecules {
+		for _, el := range mol.Elements {
+			if _, exists := lookupElement(el.Symbol); !exists {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func (c Compound) GetName() string {
 	var str string
 	str += c.Name
@@ -298,10 +296,17 @@ func (c Compound) GetName() string {
 	return str
 }
 
+// ParseFormula parses a chemical formula and names it from CompoundTable.
+// Input that isn't a valid formula of known elements is looked up as a compound name instead (e.g. "sodium chloride").
 func ParseFormula(formula string) (Compound, error) {
 	p := NewParser(formula)
 	
 	compound, err := p.ParseCompound()
+	if err != nil || !compound.isKnown() {
+		if key, exists := compoundNames[strings.ToLower(strings.TrimSpace(formula))]; exists {
+			return CompoundTable[key], nil
+		}
+	}
 	if err != nil {
 		return compound, err
 	}
@@ -314,68 +319,3 @@ func ParseFormula(formula string) (Compound, error) {
 	
 	return compound, nil
 }
-
-// DrawPeriodicTable highlights elements in the molecule
-func DrawPeriodicTable(molecule Molecule) {
-	// Create a 2D grid for the main periodic table (7 periods, 18 groups)
-	table := make([][]string, 7)
-	for i := 0; i < 7; i++ {
-		table[i] = make([]string, 18)
-	}
-
-	// Create a separate grid for Lanthanides and Actinides
-	lanthanides := make([]string, 15) // 57 (La) to 71 (Lu)
-	actinides := make([]string, 15)   // 89 (Ac) to 103 (Lr)
-
-	// Populate the main table with element symbols
-	for _, el := range ElementTable {
-		if el.Number >= 57 && el.Number <= 71 {
-			lanthanides[el.Number-57] = el.Symbol
-		} else if el.Number >= 89 && el.Number <= 103 {
-			actinides[el.Number-89] = el.Symbol
-		} else if el.Period <= 7 && el.Group <= 18 && el.Group > 0 {
-			table[el.Period-1][el.Group-1] = el.Symbol
-		}
-	}
-
-	// Check which elements are in the molecule for highlighting
-	highlighted := make(map[string]bool)
-	for _, el := range molecule.Elements {
-		highlighted[el.Symbol] = true
-	}
-
-	// Print the main periodic table, highlighting elements in the molecule
-	for _, row := range table {
-		for _, el := range row {
-			if el == "" {
-				fmt.Printf("%4s", "")
-			} else if highlighted[el] {
-				fmt.Fprintf(colorable.NewColorableStdout(), "\x1b[0;92m%4s\x1b[0m", el)
-			} else {
-				fmt.Printf("%4s", el)
-			}
-		}
-		fmt.Println()
-	}
-
-	// Print the Lanthanide and Actinide series, highlighting elements in the molecule
-	fmt.Println()
-	fmt.Print("        ")
-	for _, el := range lanthanides {
-		if highlighted[el] {
-			fmt.Fprintf(colorable.NewColorableStdout(), "\x1b[0;92m%4s\x1b[0m", el)
-		} else {
-			fmt.Printf("%4s", el)
-		}
-	}
-	fmt.Println()
-	fmt.Print("        ")
-	for _, el := range actinides {
-		if highlighted[el] {
-			fmt.Fprintf(colorable.NewColorableStdout(), "\x1b[0;92m%4s\x1b[0m", el)
-		} else {
-			fmt.Printf("%4s", el)
-		}
-	}
-	fmt.Println()
-}
@@ -0,0 +1,67 @@
+package elements
+
+import (
+	"maps"
+	"os"
+	"testing"
+)
+
+// saveTables restores the data tables when the test ends, so that tests loading or replacing them don't
+// depend on the order they run in
+func saveTables(t *testing.T) {
+	elements, compounds, names := maps.Clone(ElementTable), maps.Clone(CompoundTable), maps.Clone(compoundNames)
+	lines, nuclides := maps.Clone(LineTable), maps.Clone(NuclideTable)
+	t.Cleanup(func() {
+		ElementTable, CompoundTable, compoundNames = elements, compounds, names
+		LineTable, NuclideTable = lines, nuclides
+	})
+}
+
+// loadTestElements loads the bundled elements into ElementTable until the test ends
+func loadTestElements(t *testing.T) {
+	t.Helper()
+	saveTables(t)
+	data, err := os.ReadFile("../data/elements.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadElements(string(data)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// loadTestMolecules loads the bundled elements and molecules until the test ends
+func loadTestMolecules(t *testing.T) {
+	t.Helper()
+	loadTestElements(t)
+	data, err := os.ReadFile("../data/molecules.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	compounds, err := ReadMolecules(string(data))
+	if IsInvalid(err) {
+		t.Fatal(err)
+	}
+	MergeMolecules(compounds)
+}
+
+func TestParseFormulaByName(t *testing.T) {
+	loadTestMolecules(t)
+	tests := []struct {
+		name, want string
+	}{
+		{"sodium chloride", "NaCl"},
+		{"Sodium Chloride", "NaCl"},
+		{"deuterium oxide", "D2O"},
+	}
+	for _, test := range tests {
+		compound, err := ParseFormula(test.name)
+		if err != nil {
+			t.Errorf("ParseFormula(%q): %v", test.name, err)
+			continue
+		}
+		if got := compound.ToString(); got != test.want {
+			t.Errorf("ParseFormula(%q) = %s, want %s", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package elements
+
+import (
+	"math"
+	"sort"
+)
+
+// Excitation is a configuration reached by promoting one electron of the ground state to a higher subshell
+type Excitation struct {
+	Configuration ElectronConfiguration
+	From, To      string       // Orbitals the electron leaves and enters
+	Energy        float64      // Estimated excitation energy in eV by Slater's rules
+	Terms         []TermSymbol // Levels of the excited configuration, as from Terms
+}
+
+// ExcitedConfigurations returns the low-lying excited configurations of the element or its ion
+func (el Element) ExcitedConfigurations() []Excitation {
+	return el.GetElectronConfiguration().Excitations(el.Number)
+}
+
+// Excitations returns the configurations reached by moving one electron from the outermost or an open subshell
+// to an empty or partly filled subshell up to the next shell, for a nucleus of charge z. Each has an estimated
+// energy, the rise in total energy with every electron's orbital energy from Slater's rules.
+// The estimate is rough: Slater's rules give ns and np the same energy, so Na 3s→3p comes out at 0 eV, and leave
+// out the penetration of s orbitals, so Na 3s→3d comes out below 3s→4s. The excitations are therefore sorted by the
+// filling order of the subshell the electron enters, and by energy within it. Promotions the estimate puts below
+// the ground state (such as Gd 6s→4f) are left out, since no excitation lies below the ground state.
+func (ec ElectronConfiguration) Excitations(z int) []Excitation {
+	outermost, ok := ec.OutermostSubshell()
+	if !ok {
+		return nil
+	}
+	maxN := getPrincipalQuantumNumber(outermost.Orbital) + 1
+
+	// Electrons may leave the outermost subshell or any open one
+	var sources []string
+	for _, subshell := range ec.Subshells {
+		if subshell.Orbital == outermost.Orbital || (subshell.Electrons > 0 && subshell.Electrons < subshellCapacity[subshell.Orbital[len(subshell.Orbital)-1:]]) {
+			sources = append(sources, subshell.Orbital)
+		}
+	}
+
+	var excitations []Excitation
+	for _, from := range sources {
+		for _, to := range fillingOrder {
+			if to == from || getPrincipalQuantumNumber(to) > maxN || ec.count(to) >= subshellCapacity[to[len(to)-1:]] {
+				continue
+			}
+			// Only promotions: the electron moves to a subshell that fills later or lies in a higher shell
+			if fillingIndex(to) < fillingIndex(from) && getPrincipalQuantumNumber(to) <= getPrincipalQuantumNumber(from) {
+				continue
+			}
+
+			excited := ec.promote(from, to)
+			// Round away float noise so that degenerate promotions come out at exactly 0 rather than -0
+			energy := math.Round((excited.slaterEnergy(z)-ec.slaterEnergy(z))*1e6)/1e6 + 0
+			if energy < 0 {
+				continue
+			}
+			excitations = append(excitations, Excitation{
+				Configuration: excited,
+				From:          from,
+				To:            to,
+				Energy:        energy,
+				Terms:         excited.Terms(),
+			})
+		}
+	}
+
+	sort.SliceStable(excitations, func(i, j int) bool {
+		if a, b := fillingIndex(excitations[i].To), fillingIndex(excitations[j].To); a != b {
+			return a < b
+		}
+		return excitations[i].Energy < excitations[j].Energy
+	})
+	return excitations
+}
+
+// promote returns a copy of the configuration with one electron moved between orbitals. An emptied subshell is
+// dropped and a new one is placed in filling order.
+func (ec ElectronConfiguration) promote(from, to string) ElectronConfiguration {
+	counts := make(map[string]int)
+	for _, subshell := range ec.Subshells {
+		counts[subshell.Orbital] += subshell.Electrons
+	}
+	counts[from]--
+	counts[to]++
+
+	var promoted ElectronConfiguration
+	added := make(map[string]bool)
+	for _, subshell := range ec.Subshells {
+		if counts[subshell.Orbital] > 0 {
+			promoted.Subshells = append(promoted.Subshells, Subshell{subshell.Orbital, counts[subshell.Orbital]})
+		}
+		added[subshell.Orbital] = true
+	}
+	if !added[to] {
+		// Insert the new subshell before the first subshell that fills after it
+		inserted := false
+		for i, subshell := range promoted.Subshells {
+			if fillingIndex(subshell.Orbital) > fillingIndex(to) {
+				promoted.Subshells = append(promoted.Subshells[:i], append([]Subshell{{to, 1}}, promoted.Subshells[i:]...)...)
+				inserted = true
+				break
+			}
+		}
+		if !inserted {
+			promoted.Subshells = append(promoted.Subshells, Subshell{to, 1})
+		}
+	}
+	return promoted
+}
+
+// fillingIndex returns the position of an orbital in fillingOrder
+func fillingIndex(orbital string) int {
+	for i, o := range fillingOrder {
+		if o == orbital {
+			return i
+		}
+	}
+	return len(fillingOrder)
+}
@@ -0,0 +1,29 @@
+package elements
+
+import "testing"
+
+func TestExcitationsSodium(t *testing.T) {
+	excitations := GenerateElectronConfiguration(11).Excitations(11)
+	want := []string{"3p", "4s", "3d", "4p"}
+	if len(excitations) < len(want) {
+		t.Fatalf("Na has %d excitations, want at least %d", len(excitations), len(want))
+	}
+	for i, to := range want {
+		if e := excitations[i]; e.From != "3s" || e.To != to {
+			t.Errorf("Na excitation %d = %s→%s, want 3s→%s", i+1, e.From, e.To, to)
+		}
+	}
+	if got := excitations[0].Configuration.Abbreviated(); got != "[Ne] 3p1" {
+		t.Errorf("Na lowest excited configuration = %s, want [Ne] 3p1", got)
+	}
+}
+
+func TestExcitationsNotBelowGround(t *testing.T) {
+	for z := 1; z <= 103; z++ {
+		for _, e := range GenerateElectronConfiguration(z).Excitations(z) {
+			if e.Energy < 0 {
+				t.Errorf("element %d: %s→%s has energy %g eV", z, e.From, e.To, e.Energy)
+			}
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package elements
+
+import (
+	"sort"
+)
+
+// ligandCharges are the charges of common ligands, keyed by formula, used to find the oxidation state
+// of the metal at the centre of a complex ion
+var ligandCharges = map[string]int{
+	"F": -1, "Cl": -1, "Br": -1, "I": -1,
+	"CN": -1, "OH": -1, "SCN": -1, "NCS": -1, "NO2": -1, "N3": -1,
+	"O": -2, "S": -2, "C2O4": -2, "CO3": -2, "SO4": -2,
+	"H2O": 0, "NH3": 0, "CO": 0, "NO": 0,
+}
+
+// IonConfiguration generates the ground-state electron configuration of an ion with the given charge.
+// Cations lose electrons from the neutral atom's outermost shell first, then from its (n-1)d and (n-2)f subshells,
+// so transition metals lose their ns electrons before (n-1)d and lanthanides lose 6s and 5d before 4f.
+// Anions gain electrons in filling order.
+func IonConfiguration(atomicNumber int, charge int) ElectronConfiguration {
+	neutral := GenerateElectronConfiguration(atomicNumber)
+	configuration := ElectronConfiguration{Subshells: append([]Subshell(nil), neutral.Subshells...)}
+
+	// Remove electrons for a cation
+	order := ionizationOrder(neutral)
+	for removed := 0; removed < charge && len(configuration.Subshells) > 0; removed++ {
+		for _, orbital := range order {
+			i := configuration.index(orbital)
+			if i < 0 {
+				continue
+			}
+			configuration.Subshells[i].Electrons--
+			if configuration.Subshells[i].Electrons == 0 {
+				configuration.Subshells = append(configuration.Subshells[:i], configuration.Subshells[i+1:]...)
+			}
+			break
+		}
+	}
+
+	// Add electrons for an anion
+	added := 0
+	for _, orbital := range fillingOrder {
+		if added >= -charge {
+			break
+		}
+		room := subshellCapacity[orbital[len(orbital)-1:]] - configuration.count(orbital)
+		electrons := min(room, -charge-added)
+		if electrons <= 0 {
+			continue
+		}
+		added += electrons
+
+		found := false
+		for i := range configuration.Subshells {
+			if configuration.Subshells[i].Orbital == orbital {
+				configuration.Subshells[i].Electrons += electrons
+				found = true
+			}
+		}
+		if !found {
+			configuration.Subshells = append(configuration.Subshells, Subshell{Orbital: orbital, Electrons: electrons})
+		}
+	}
+
+	return configuration
+}
+
+// ionizationOrder returns the occupied orbitals of a neutral configuration in the order a cation loses their electrons:
+// the outermost shell n from its highest l down, then (n-1)d, then (n-2)f, then the core from the outside in.
+// The shell n is fixed by the neutral atom, so an open 4f is emptied before the 5p beneath the 6s that was lost.
+func ionizationOrder(neutral ElectronConfiguration) []string {
+	outer, ok := neutral.OutermostSubshell()
+	if !ok {
+		return nil
+	}
+	valence := getPrincipalQuantumNumber(outer.Orbital)
+	rank := func(orbital string) int {
+		n, l := getPrincipalQuantumNumber(orbital), getAzimuthalQuantumNumber(orbital)
+		switch {
+		case n == valence:
+			return 0
+		case n == valence-1 && l == 2:
+			return 1
+		case n == valence-2 && l == 3:
+			return 2
+		}
+		return 3
+	}
+
+	var order []string
+	for _, subshell := range neutral.Subshells {
+		if subshell.Electrons > 0 {
+			order = append(order, subshell.Orbital)
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if ri, rj := rank(order[i]), rank(order[j]); ri != rj {
+			return ri < rj
+		}
+		ni, nj := getPrincipalQuantumNumber(order[i]), getPrincipalQuantumNumber(order[j])
+		if ni != nj {
+			return ni > nj
+		}
+		return getAzimuthalQuantumNumber(order[i]) > getAzimuthalQuantumNumber(order[j])
+	})
+	return order
+}
+
+// Isoelectronic reports whether two configurations have the same electrons in the same subshells,
+// regardless of the order they are listed in
+func (ec ElectronConfiguration) Isoelectronic(other ElectronConfiguration) bool {
+	if ec.Electrons() != other.Electrons() {
+		return false
+	}
+	for _, subshell := range ec.Subshells {
+		if other.count(subshell.Orbital) != subshell.Electrons {
+			return false
+		}
+	}
+	return true
+}
+
+// IsoelectronicElement returns the neutral element with the same configuration as an ion, if there is one (e.g. Ne for Na+)
+func (el Element) IsoelectronicElement() (Element, bool) {
+	if el.Charge == 0 {
+		return Element{}, false
+	}
+	configuration := el.GetElectronConfiguration()
+	for _, other := range ElementTable {
+		if other.Number == configuration.Electrons() && GenerateElectronConfiguration(other.Number).Isoelectronic(configuration) {
+			return other, true
+		}
+	}
+	return Element{}, false
+}
+
+// CentralIon returns the metal ion at the centre of a charged complex such as [Fe(CN)6]-4, with its charge set to
+// its oxidation state. The complex must have a single metal atom and otherwise only ligands from ligandCharges.
+func (c Compound) CentralIon() (Element, bool) {
+	charge := c.GetCharge()
+	if charge == 0 {
+		return Element{}, false
+	}
+
+	var metal Element
+	metals := 0
+	for _, mol := range c.Molecules {
+		if len(mol.Elements) == 1 && isMetal(mol.Elements[0]) {
+			metal = mol.Elements[0]
+			metals++
+			continue
+		}
+		formula := Molecule{Elements: mol.Elements}.ToString()
+		ligand, known := ligandCharges[formula]
+		if !known {
+			return Element{}, false
+		}
+		charge -= ligand
+	}
+	if metals != 1 {
+		return Element{}, false
+	}
+
+	// The metal's own charge, if written, is already counted in the total
+	metal.Charge = charge
+	return metal, true
+}
+
+// isMetal reports whether an element is a metal by its category
+func isMetal(el Element) bool {
+	switch el.Category {
+	case "Alkali Metal", "Alkaline Earth Metal", "Transition Metal", "Post-transition Metal", "Metal", "Lanthanide", "Actinide", "Transactinide":
+		return true
+	}
+	return false
+}
+
+// IsoelectronicSeries returns the atom and ions with the same ground-state configuration as the element or ion,
+// including itself, sorted by nuclear charge. Ions are limited to the elements' oxidation states.
+func (el Element) IsoelectronicSeries() []Element {
+	series := el.GetElectronConfiguration().Species()
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].Number < series[j].Number
+	})
+	return series
+}
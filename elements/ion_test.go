@@ -0,0 +1,76 @@
+package elements
+
+import "testing"
+
+func TestIonConfiguration(t *testing.T) {
+	tests := []struct {
+		z, charge int
+		want      string
+	}{
+		// Transition metals lose ns before (n-1)d
+		{26, 2, "[Ar] 3d6"},
+		{26, 3, "[Ar] 3d5"},
+		{24, 3, "[Ar] 3d3"},
+		{29, 2, "[Ar] 3d9"},
+		{30, 2, "[Ar] 3d10"},
+		{46, 2, "[Kr] 4d8"},
+		{47, 1, "[Kr] 4d10"},
+		{79, 3, "[Xe] 4f14 5d8"},
+		// Post-transition metals lose np before ns
+		{50, 2, "[Kr] 5s2 4d10"},
+		{82, 2, "[Xe] 6s2 4f14 5d10"},
+		// Lanthanides and actinides lose 6s and 5d, or 7s and 6d, before f
+		{60, 3, "[Xe] 4f3"},
+		{63, 3, "[Xe] 4f6"},
+		{64, 3, "[Xe] 4f7"},
+		{92, 4, "[Rn] 5f2"},
+		{95, 3, "[Rn] 5f6"},
+		// Anions fill in order
+		{17, -1, "[Ne] 3s2 3p6"},
+		{8, -2, "[He] 2s2 2p6"},
+	}
+	for _, test := range tests {
+		if got := IonConfiguration(test.z, test.charge).Abbreviated(); got != test.want {
+			t.Errorf("IonConfiguration(%d, %+d) = %s, want %s", test.z, test.charge, got, test.want)
+		}
+	}
+}
+
+func TestIonConfigurationNobleGas(t *testing.T) {
+	tests := []struct {
+		z, charge, gas int
+	}{
+		{11, 1, 10}, // Na+
+		{57, 3, 54}, // La+3
+		{58, 4, 54}, // Ce+4
+		{90, 4, 86}, // Th+4
+	}
+	for _, test := range tests {
+		got := IonConfiguration(test.z, test.charge)
+		if !got.Isoelectronic(GenerateElectronConfiguration(test.gas)) {
+			t.Errorf("IonConfiguration(%d, %+d) = %s, want the configuration of element %d", test.z, test.charge, got.Abbreviated(), test.gas)
+		}
+	}
+}
+
+func TestIsoelectronicSeries(t *testing.T) {
+	loadTestElements(t)
+	tests := []struct {
+		symbol string
+		want   []string
+	}{
+		{"Xe", []string{"Te-2", "I-", "Xe", "Cs+", "Ba+2", "La+3", "Ce+4"}},
+		{"Ne", []string{"O-2", "F-", "Ne", "Na+", "Mg+2", "Al+3"}},
+	}
+	for _, test := range tests {
+		found := make(map[string]bool)
+		for _, el := range ElementTable[test.symbol].IsoelectronicSeries() {
+			found[el.ToString()] = true
+		}
+		for _, want := range test.want {
+			if !found[want] {
+				t.Errorf("IsoelectronicSeries of %s is missing %s", test.symbol, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package elements
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// isotopeLabel matches an element symbol with an optional mass number before it, written as [13C], ^13C or ¹³C
+var isotopeLabel = regexp.MustCompile(`\[(\d+)([A-Z][a-z]*)\]|\^(\d+)([A-Z][a-z]*)|([⁰¹²³⁴⁵⁶⁷⁸⁹]+)([A-Z][a-z]*)|([A-Z][a-z]*)`)
+
+// superscriptDigits reads superscript digits as plain ones
+var superscriptDigits = strings.NewReplacer("⁰", "0", "¹", "1", "²", "2", "³", "3", "⁴", "4", "⁵", "5", "⁶", "6", "⁷", "7", "⁸", "8", "⁹", "9")
+
+// IsotopeLabels returns the nuclides of a formula whose isotopes are labelled with a mass number before the symbol,
+// as [13C], ^13C or ¹³C, or with D and T for hydrogen-2 and -3. Unlabelled elements count as their most abundant
+// nuclide in NuclideTable, if it has one. The formula without its labels is returned too, e.g. "CH4" for "¹³CH4".
+func IsotopeLabels(formula string) ([]Nuclide, string, error) {
+	var nuclides []Nuclide
+	seen := make(map[string]bool)
+	add := func(n Nuclide) {
+		if !seen[n.ToString()] {
+			seen[n.ToString()] = true
+			nuclides = append(nuclides, n)
+		}
+	}
+
+	var stripped strings.Builder
+	last := 0
+	for _, m := range isotopeLabel.FindAllStringSubmatchIndex(formula, -1) {
+		stripped.WriteString(formula[last:m[0]])
+		last = m[1]
+		group := func(i int) string {
+			if m[2*i] < 0 {
+				return ""
+			}
+			return formula[m[2*i]:m[2*i+1]]
+		}
+
+		var number, symbol string
+		for i := 1; i <= 5; i += 2 {
+			if group(i+1) != "" {
+				number, symbol = superscriptDigits.Replace(group(i)), group(i+1)
+			}
+		}
+		if number == "" {
+			symbol = group(7)
+			switch symbol {
+			case "D":
+				symbol, number = "H", "2"
+			case "T":
+				symbol, number = "H", "3"
+			}
+		}
+		stripped.WriteString(symbol)
+
+		if number == "" {
+			if err := checkLabel(symbol); err != nil {
+				return nil, "", err
+			}
+			if n, ok := mostAbundant(symbol); ok {
+				add(n)
+			}
+			continue
+		}
+		a, _ := strconv.Atoi(number)
+		n, err := ParseNuclide(nuclideName(symbol, a, false))
+		if err != nil {
+			return nil, "", err
+		}
+		add(n)
+	}
+	stripped.WriteString(formula[last:])
+
+	if _, err := ParseFormula(stripped.String()); err != nil {
+		return nil, "", err
+	}
+	return nuclides, stripped.String(), nil
+}
+
+// mostAbundant returns the element's nuclide with the highest natural abundance
+func mostAbundant(symbol string) (Nuclide, bool) {
+	var best Nuclide
+	found := false
+	for _, n := range NuclideTable {
+		if n.Symbol == symbol && n.Abundance.Valid && (!found || n.Abundance.Value > best.Abundance.Value) {
+			best, found = n, true
+		}
+	}
+	return best, found
+}
+
+// checkLabel reports an element symbol that isn't in ElementTable
+func checkLabel(symbol string) error {
+	if _, ok := ElementTable[symbol]; !ok {
+		return fmt.Errorf("unknown element %q", symbol)
+	}
+	return nil
+}
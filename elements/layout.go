@@ -0,0 +1,207 @@
+package elements
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// cell is one position of the periodic table grid
+type cell struct {
+	el    Element   // Element shown in the cell, if any
+	label string    // Text of a compact cell without an element, e.g. the f-block markers
+	lines [3]string // Text of a tile without an element, e.g. "57-71" for the lanthanides placeholder
+}
+
+// boxed reports whether the cell is drawn as a tile in the wide layout
+func (c cell) boxed() bool {
+	return c.el.Symbol != "" || c.lines[0] != ""
+}
+
+// gridRow is one row of the periodic table grid
+type gridRow struct {
+	cells []cell
+	gap   bool // Separate the row from the one above, as for the f-block under the main table
+}
+
+// Sizes of the two cell styles
+const (
+	compactWidth = 4 // "  Fe"
+	tileWidth    = 6 // Inner width of a tile, plus one column for its left border
+)
+
+// buildGrid places the elements of ElementTable on an 18-column grid with the f-block below it,
+// or on a 32-column grid with the f-block inline when long is set
+func buildGrid(long bool) []gridRow {
+	cols, rows := 18, 10
+	if long {
+		cols, rows = 32, 7
+	}
+	grid := make([]gridRow, rows)
+	for i := range grid {
+		grid[i].cells = make([]cell, cols)
+	}
+
+	for _, el := range ElementTable {
+		row, col := -1, -1
+		switch {
+		case long && el.Number >= 57 && el.Number <= 70:
+			row, col = 5, el.Number-55
+		case long && el.Number >= 89 && el.Number <= 102:
+			row, col = 6, el.Number-87
+		case long && el.Number == 71:
+			row, col = 5, 16 // Lu and Lr head group 3 in the long form
+		case long && el.Number == 103:
+			row, col = 6, 16
+		case !long && el.Number >= 57 && el.Number <= 71:
+			row, col = 8, el.Number-55
+		case !long && el.Number >= 89 && el.Number <= 103:
+			row, col = 9, el.Number-87
+		case el.Period >= 1 && el.Period <= 7 && el.Group >= 1 && el.Group <= 18:
+			row, col = el.Period-1, el.Group-1
+			if long && el.Group >= 3 {
+				col += 14
+			}
+		}
+		if row >= 0 {
+			grid[row].cells[col].el = el
+		}
+	}
+
+	if !long {
+		// Point from group 3 to the f-block rows
+		grid[5].cells[2] = cell{label: "*", lines: [3]string{"57-71", "La-Lu"}}
+		grid[6].cells[2] = cell{label: "**", lines: [3]string{"89-103", "Ac-Lr"}}
+		grid[8].cells[1].label = "*"
+		grid[9].cells[1].label = "**"
+		grid = append(grid[:7], grid[8:]...)
+		grid[7].gap = true
+	}
+	return grid
+}
+
+// gridWidth returns the number of terminal columns a grid takes in the given style
+func gridWidth(grid []gridRow, tiles bool) int {
+	cols := len(grid[0].cells)
+	if tiles {
+		return cols*(tileWidth+1) + 1
+	}
+	return cols * compactWidth
+}
+
+// formatMass formats an atomic mass, rounded to fit the inner width of a tile
+func formatMass(amu float64) string {
+	s := strconv.FormatFloat(amu, 'f', -1, 64)
+	if len(s) <= tileWidth {
+		return s
+	}
+	digits := len(strconv.Itoa(int(amu)))
+	return strconv.FormatFloat(amu, 'f', max(tileWidth-digits-1, 0), 64)
+}
+
+// tileLines returns the three lines of text shown in a tile
+func tileLines(c cell) [3]string {
+	if c.el.Symbol == "" {
+		return c.lines
+	}
+	return [3]string{strconv.Itoa(c.el.Number), "  " + c.el.Symbol, formatMass(c.el.Amu)}
+}
+
+// boxChars maps the arms of a border junction (up, down, left, right) to a box-drawing character
+var boxChars = map[[4]bool]string{
+	{false, false, false, false}: " ",
+	{false, true, false, true}:   "┌",
+	{false, true, true, false}:   "┐",
+	{true, false, false, true}:   "└",
+	{true, false, true, false}:   "┘",
+	{true, true, false, false}:   "│",
+	{false, false, true, true}:   "─",
+	{true, true, false, true}:    "├",
+	{true, true, true, false}:    "┤",
+	{false, true, true, true}:    "┬",
+	{true, false, true, true}:    "┴",
+	{true, true, true, true}:     "┼",
+	{true, false, false, false}:  "│",
+	{false, true, false, false}:  "│",
+	{false, false, true, false}:  "─",
+	{false, false, false, true}:  "─",
+}
+
+// borderLine draws the horizontal border between two grid rows, either of which may be nil
+func borderLine(above, below []cell, cols int) string {
+	boxed := func(cells []cell, col int) bool {
+		return cells != nil && col >= 0 && col < cols && cells[col].boxed()
+	}
+	var sb strings.Builder
+	for col := 0; col <= cols; col++ {
+		ul, ur := boxed(above, col-1), boxed(above, col)
+		dl, dr := boxed(below, col-1), boxed(below, col)
+		sb.WriteString(boxChars[[4]bool{ul || ur, dl || dr, ul || dl, ur || dr}])
+		if col == cols {
+			break
+		}
+		if ur || dr {
+			sb.WriteString(strings.Repeat("─", tileWidth))
+		} else {
+			sb.WriteString(strings.Repeat(" ", tileWidth))
+		}
+	}
+	return strings.TrimRight(sb.String(), " ")
+}
+
+// drawCompact prints the grid with one four-character cell per element.
+// decorate styles the padded text of an element's cell for the given line of the cell.
+func drawCompact(out io.Writer, grid []gridRow, decorate func(el Element, line int, text string) string) {
+	for _, row := range grid {
+		if row.gap {
+			fmt.Fprintln(out)
+		}
+		for _, c := range row.cells {
+			if c.el.Symbol == "" {
+				fmt.Fprintf(out, "%*s", compactWidth, c.label)
+			} else {
+				fmt.Fprint(out, decorate(c.el, 1, fmt.Sprintf("%*s", compactWidth, c.el.Symbol)))
+			}
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// drawTiles prints the grid as box-drawn tiles showing atomic number, symbol and mass
+func drawTiles(out io.Writer, grid []gridRow, decorate func(el Element, line int, text string) string) {
+	cols := len(grid[0].cells)
+	var above []cell
+	for _, row := range grid {
+		if row.gap {
+			if above != nil {
+				fmt.Fprintln(out, borderLine(above, nil, cols))
+			}
+			fmt.Fprintln(out)
+			above = nil
+		}
+		fmt.Fprintln(out, borderLine(above, row.cells, cols))
+
+		for line := 0; line < 3; line++ {
+			var sb strings.Builder
+			for col, c := range row.cells {
+				if c.boxed() || (col > 0 && row.cells[col-1].boxed()) {
+					sb.WriteString("│")
+				} else {
+					sb.WriteString(" ")
+				}
+				text := fmt.Sprintf("%-*s", tileWidth, tileLines(c)[line])
+				if c.el.Symbol != "" {
+					text = decorate(c.el, line, text)
+				}
+				sb.WriteString(text)
+			}
+			if row.cells[cols-1].boxed() {
+				sb.WriteString("│")
+			}
+			fmt.Fprintln(out, strings.TrimRight(sb.String(), " "))
+		}
+		above = row.cells
+	}
+	fmt.Fprintln(out, borderLine(above, nil, cols))
+}
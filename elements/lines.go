@@ -0,0 +1,139 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// EmissionLine is a strong line in the emission spectrum of an element
+type EmissionLine struct {
+	Symbol     string
+	Wavelength float64 // nm, in air
+	Intensity  float64 // Relative to the other lines of the same element
+}
+
+// LineTable maps element symbols to their emission lines, shortest wavelength first
+var LineTable = map[string][]EmissionLine{}
+
+// ReadLines parses and validates CSV data of emission lines without loading it.
+// Columns are found by the header row; symbol, wavelength and intensity are required.
+// Every problem in the data is reported in the returned *DataError, along with the valid lines.
+func ReadLines(data string) ([]EmissionLine, error) {
+	errs := &DataError{}
+	table := readTable(data, []string{"symbol", "wavelength", "intensity"}, errs)
+	if table == nil {
+		return nil, errs.err()
+	}
+
+	var lines []EmissionLine
+	seen := make(map[string]int)
+	for _, row := range table.rows {
+		before := len(errs.Rows)
+
+		symbol := table.get(row, "symbol")
+		if _, ok := ElementTable[symbol]; !ok {
+			errs.add(row.line, "symbol", "unknown element %q", symbol)
+		}
+		for _, column := range []string{"wavelength", "intensity"} {
+			if table.get(row, column) == "" {
+				errs.add(row.line, column, "missing value")
+			}
+		}
+		wavelength := parseOptional(table, row, "wavelength", true, errs)
+		intensity := parseOptional(table, row, "intensity", true, errs)
+		if len(errs.Rows) > before {
+			continue
+		}
+
+		key := symbol + " " + strconv.FormatFloat(wavelength.Value, 'f', -1, 64)
+		if first, dup := seen[key]; dup {
+			errs.add(row.line, "wavelength", "duplicate line %s nm of %s (first defined on line %d)", table.get(row, "wavelength"), symbol, first)
+			continue
+		}
+		seen[key] = row.line
+
+		lines = append(lines, EmissionLine{Symbol: symbol, Wavelength: wavelength.Value, Intensity: intensity.Value})
+	}
+
+	return lines, errs.err()
+}
+
+// MergeLines adds lines to LineTable. An element's lines replace all of its existing ones, since a dataset
+// of lines is only meaningful as a whole. It returns the symbols of the replaced elements.
+func MergeLines(lines []EmissionLine) []string {
+	bySymbol := make(map[string][]EmissionLine)
+	var symbols []string
+	for _, line := range lines {
+		if _, ok := bySymbol[line.Symbol]; !ok {
+			symbols = append(symbols, line.Symbol)
+		}
+		bySymbol[line.Symbol] = append(bySymbol[line.Symbol], line)
+	}
+
+	var overridden []string
+	for _, symbol := range symbols {
+		if _, exists := LineTable[symbol]; exists {
+			overridden = append(overridden, symbol)
+		}
+		sorted := bySymbol[symbol]
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Wavelength < sorted[j].Wavelength })
+		LineTable[symbol] = sorted
+	}
+	return overridden
+}
+
+// LineMatch is an element that explains some of a set of measured wavelengths
+type LineMatch struct {
+	Symbol    string
+	Lines     []EmissionLine // The element's nearest line to each measured wavelength, or a zero line if none is within tolerance
+	Explained int            // Number of measured wavelengths explained
+	Intensity float64        // Sum of the relative intensities of the matched lines
+}
+
+// IdentifyLines returns the elements with a line within tolerance nm of at least one measured wavelength,
+// those explaining the most wavelengths first and then those whose matched lines are strongest.
+// Wavelengths must be positive and the tolerance not negative.
+func IdentifyLines(measured []float64, tolerance float64) ([]LineMatch, error) {
+	for _, wavelength := range measured {
+		if !(wavelength > 0) || math.IsInf(wavelength, 0) {
+			return nil, fmt.Errorf("wavelength %g nm must be positive", wavelength)
+		}
+	}
+	if !(tolerance >= 0) || math.IsInf(tolerance, 0) {
+		return nil, fmt.Errorf("tolerance %g nm must not be negative", tolerance)
+	}
+
+	var matches []LineMatch
+	for symbol, lines := range LineTable {
+		match := LineMatch{Symbol: symbol, Lines: make([]EmissionLine, len(measured))}
+		for i, wavelength := range measured {
+			best := math.Inf(1)
+			for _, line := range lines {
+				if d := math.Abs(line.Wavelength - wavelength); d <= tolerance && d < best {
+					best = d
+					match.Lines[i] = line
+				}
+			}
+			if match.Lines[i].Symbol != "" {
+				match.Explained++
+				match.Intensity += match.Lines[i].Intensity
+			}
+		}
+		if match.Explained > 0 {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Explained != matches[j].Explained {
+			return matches[i].Explained > matches[j].Explained
+		}
+		if matches[i].Intensity != matches[j].Intensity {
+			return matches[i].Intensity > matches[j].Intensity
+		}
+		return matches[i].Symbol < matches[j].Symbol
+	})
+	return matches, nil
+}
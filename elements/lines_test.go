@@ -0,0 +1,32 @@
+package elements
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIdentifyLinesRejectsWavelengths(t *testing.T) {
+	for _, wavelength := range []float64{0, -656.3, math.NaN(), math.Inf(1)} {
+		if _, err := IdentifyLines([]float64{656.3, wavelength}, 0.5); err == nil {
+			t.Errorf("IdentifyLines accepted a wavelength of %g nm", wavelength)
+		}
+	}
+	if _, err := IdentifyLines([]float64{656.3}, -1); err == nil {
+		t.Error("IdentifyLines accepted a negative tolerance")
+	}
+}
+
+func TestIdentifyLines(t *testing.T) {
+	saveTables(t)
+	LineTable = map[string][]EmissionLine{
+		"H":  {{Symbol: "H", Wavelength: 656.279, Intensity: 500}},
+		"Na": {{Symbol: "Na", Wavelength: 588.995, Intensity: 1000}},
+	}
+	matches, err := IdentifyLines([]float64{656.3}, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Symbol != "H" {
+		t.Errorf("IdentifyLines(656.3) = %v, want H", matches)
+	}
+}
@@ -0,0 +1,360 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DecayMode is a way a radioactive nuclide decays
+type DecayMode string
+
+const (
+	AlphaDecay         DecayMode = "α"
+	BetaMinusDecay     DecayMode = "β-"
+	BetaPlusDecay      DecayMode = "β+"
+	ElectronCapture    DecayMode = "EC"
+	IsomericTransition DecayMode = "IT"
+	SpontaneousFission DecayMode = "SF"
+)
+
+// decayModeNames maps the names used in the data to decay modes
+var decayModeNames = map[string]DecayMode{
+	"alpha": AlphaDecay, "α": AlphaDecay,
+	"beta-": BetaMinusDecay, "β-": BetaMinusDecay,
+	"beta+": BetaPlusDecay, "β+": BetaPlusDecay,
+	"ec": ElectronCapture,
+	"it": IsomericTransition,
+	"sf": SpontaneousFission,
+}
+
+// Decay is one branch of a nuclide's decay
+type Decay struct {
+	Mode     DecayMode
+	Branch   float64 // Fraction of decays taking this branch
+	Daughter string  // Nuclide produced (e.g. "Th-234"), empty for spontaneous fission
+}
+
+// Nuclide is an isotope of an element, or a metastable state of one
+type Nuclide struct {
+	Symbol    string
+	Z, N, A   int
+	Isomer    bool     // Metastable excited state, written with an m (e.g. Tc-99m)
+	Mass      float64  // u, of the neutral atom
+	Abundance Optional // % of the element's atoms in nature
+	HalfLife  Optional // s, missing for stable nuclides
+	Decays    []Decay  // Most likely branch first
+}
+
+// NuclideTable maps nuclide names (e.g. "U-238") to their data
+var NuclideTable = map[string]Nuclide{}
+
+// ToString returns the nuclide's name (e.g., "U-238" or "Tc-99m")
+func (n Nuclide) ToString() string {
+	return nuclideName(n.Symbol, n.A, n.Isomer)
+}
+
+// Name returns the nuclide's name with the element spelled out (e.g., "Uranium-238")
+func (n Nuclide) Name() string {
+	if el, ok := ElementTable[n.Symbol]; ok {
+		return nuclideName(el.Name, n.A, n.Isomer)
+	}
+	return n.ToString()
+}
+
+// Stable reports whether the nuclide has no known decay
+func (n Nuclide) Stable() bool {
+	return !n.HalfLife.Valid
+}
+
+// DecayConstant returns λ = ln 2 / half-life in 1/s, 0 for stable nuclides
+func (n Nuclide) DecayConstant() float64 {
+	if n.Stable() {
+		return 0
+	}
+	return math.Ln2 / n.HalfLife.Value
+}
+
+// Remaining returns the fraction of the nuclide left after t seconds
+func (n Nuclide) Remaining(t float64) float64 {
+	return math.Exp(-n.DecayConstant() * t)
+}
+
+// Isotopes returns the other nuclides of the same element in NuclideTable, lightest first
+func (n Nuclide) Isotopes() []Nuclide {
+	var isotopes []Nuclide
+	for _, other := range NuclideTable {
+		if other.Z == n.Z && other.ToString() != n.ToString() {
+			isotopes = append(isotopes, other)
+		}
+	}
+	sortNuclides(isotopes)
+	return isotopes
+}
+
+// sortNuclides orders nuclides by Z, then A, with a metastable state after its ground state
+func sortNuclides(nuclides []Nuclide) {
+	sort.Slice(nuclides, func(i, j int) bool {
+		if nuclides[i].Z != nuclides[j].Z {
+			return nuclides[i].Z < nuclides[j].Z
+		}
+		if nuclides[i].A != nuclides[j].A {
+			return nuclides[i].A < nuclides[j].A
+		}
+		return !nuclides[i].Isomer && nuclides[j].Isomer
+	})
+}
+
+// nuclideName joins an element symbol or name to a mass number
+func nuclideName(element string, a int, isomer bool) string {
+	name := fmt.Sprintf("%s-%d", element, a)
+	if isomer {
+		name += "m"
+	}
+	return name
+}
+
+// symbolOf returns the symbol of the element with the given atomic number
+func symbolOf(z int) (string, bool) {
+	for _, el := range ElementTable {
+		if el.Number == z {
+			return el.Symbol, true
+		}
+	}
+	return "", false
+}
+
+// splitNuclide splits a nuclide written as "U-238", "U238", "238U", "Uranium-238" or "Tc-99m" into its
+// element and mass number. D and T are read as H-2 and H-3.
+func splitNuclide(s string) (element string, a int, isomer bool, err error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "D":
+		return "H", 2, false, nil
+	case "T":
+		return "H", 3, false, nil
+	}
+
+	var letters, digits string
+	if i := strings.IndexFunc(s, unicode.IsLetter); i > 0 {
+		// Mass number first (e.g. "238U" or "99mTc")
+		digits, letters = s[:i], s[i:]
+		if strings.HasPrefix(letters, "m") && len(letters) > 1 && unicode.IsUpper(rune(letters[1])) {
+			letters, isomer = letters[1:], true
+		}
+	} else {
+		i := strings.IndexFunc(s, unicode.IsDigit)
+		if i < 0 {
+			return "", 0, false, fmt.Errorf("%q has no mass number, write it like U-238", s)
+		}
+		letters, digits = strings.TrimRight(s[:i], "- "), s[i:]
+		if strings.HasSuffix(digits, "m") {
+			digits, isomer = digits[:len(digits)-1], true
+		}
+	}
+
+	a, err = strconv.Atoi(digits)
+	if err != nil || a <= 0 || letters == "" {
+		return "", 0, false, fmt.Errorf("%q is not a nuclide, write it like U-238", s)
+	}
+	return letters, a, isomer, nil
+}
+
+// ParseNuclide returns the nuclide written as "U-238", "U238", "238U", "uranium-238", "Tc-99m", "D" or "T"
+func ParseNuclide(s string) (Nuclide, error) {
+	element, a, isomer, err := splitNuclide(s)
+	if err != nil {
+		return Nuclide{}, err
+	}
+	symbol := ""
+	for _, el := range ElementTable {
+		if strings.EqualFold(el.Symbol, element) || strings.EqualFold(el.Name, element) {
+			symbol = el.Symbol
+			break
+		}
+	}
+	if symbol == "" {
+		return Nuclide{}, fmt.Errorf("unknown element %q", element)
+	}
+	name := nuclideName(symbol, a, isomer)
+	n, ok := NuclideTable[name]
+	if !ok {
+		return Nuclide{}, fmt.Errorf("no data for %s", name)
+	}
+	return n, nil
+}
+
+// daughterOf returns the nuclide produced when a nuclide of the given Z and A decays by mode,
+// or "" if the daughter isn't a single nuclide or its element is unknown
+func daughterOf(z, a int, mode DecayMode) string {
+	switch mode {
+	case AlphaDecay:
+		z, a = z-2, a-4
+	case BetaMinusDecay:
+		z++
+	case BetaPlusDecay, ElectronCapture:
+		z--
+	case IsomericTransition:
+	default:
+		return ""
+	}
+	symbol, ok := symbolOf(z)
+	if !ok {
+		return ""
+	}
+	return nuclideName(symbol, a, false)
+}
+
+// parseDecays parses decay branches separated by semicolons, each a mode with an optional percentage and
+// an optional daughter after ">" (e.g. "beta- 94.4% > Ba-137m; beta- 5.6%"). A single branch may leave out
+// its percentage; otherwise every branch needs one and they must add up to 100%.
+func parseDecays(t *csvTable, row csvRow, z, a int, errs *DataError) []Decay {
+	value := t.get(row, "decay")
+	if value == "" {
+		return nil
+	}
+
+	var decays []Decay
+	total := 0.0
+	branches := strings.Split(value, ";")
+	for _, branch := range branches {
+		daughter := ""
+		if i := strings.Index(branch, ">"); i >= 0 {
+			daughter = strings.TrimSpace(branch[i+1:])
+			branch = branch[:i]
+		}
+		fields := strings.Fields(branch)
+		if len(fields) == 0 || len(fields) > 2 {
+			errs.add(row.line, "decay", "%q is not a decay mode with an optional percentage", strings.TrimSpace(branch))
+			return nil
+		}
+		mode, ok := decayModeNames[strings.ToLower(fields[0])]
+		if !ok {
+			errs.add(row.line, "decay", "unknown decay mode %q", fields[0])
+			return nil
+		}
+
+		fraction := 1.0
+		if len(fields) == 2 {
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(fields[1], "%"), 64)
+			if err != nil || !strings.HasSuffix(fields[1], "%") || percent <= 0 || percent > 100 {
+				errs.add(row.line, "decay", "%q is not a percentage", fields[1])
+				return nil
+			}
+			fraction = percent / 100
+		} else if len(branches) > 1 {
+			errs.add(row.line, "decay", "branch %q needs a percentage", strings.TrimSpace(branch))
+			return nil
+		}
+		total += fraction
+
+		if daughter == "" {
+			daughter = daughterOf(z, a, mode)
+		} else if _, _, _, err := splitNuclide(daughter); err != nil {
+			errs.add(row.line, "decay", "%v", err)
+			return nil
+		}
+		decays = append(decays, Decay{Mode: mode, Branch: fraction, Daughter: daughter})
+	}
+
+	if math.Abs(total-1) > 1e-6 {
+		errs.add(row.line, "decay", "branches add up to %g%%, not 100%%", total*100)
+	}
+	sort.SliceStable(decays, func(i, j int) bool { return decays[i].Branch > decays[j].Branch })
+	return decays
+}
+
+// ReadNuclides parses and validates CSV data of nuclides without loading it.
+// Columns are found by the header row; nuclide and mass are required, and abundance, half_life and decay
+// are optional. Half-lives carry a unit (e.g. "5730 y"); a nuclide with a half-life must have a decay and vice versa.
+// Every problem in the data is reported in the returned *DataError, along with the valid nuclides.
+func ReadNuclides(data string) ([]Nuclide, error) {
+	errs := &DataError{}
+	table := readTable(data, []string{"nuclide", "mass"}, errs)
+	if table == nil {
+		return nil, errs.err()
+	}
+
+	var nuclides []Nuclide
+	seen := make(map[string]int)
+	for _, row := range table.rows {
+		before := len(errs.Rows)
+
+		name := table.get(row, "nuclide")
+		symbol, a, isomer, err := splitNuclide(name)
+		el, known := ElementTable[symbol]
+		if err != nil {
+			errs.add(row.line, "nuclide", "%v", err)
+		} else if !known {
+			errs.add(row.line, "nuclide", "unknown element %q", symbol)
+		} else if a < el.Number {
+			errs.add(row.line, "nuclide", "mass number %d is less than the atomic number %d", a, el.Number)
+		}
+		if table.get(row, "mass") == "" {
+			errs.add(row.line, "mass", "missing value")
+		}
+		mass := parseOptional(table, row, "mass", true, errs)
+		abundance := parseOptional(table, row, "abundance", true, errs)
+		if abundance.Value > 100 {
+			errs.add(row.line, "abundance", "%g%% is more than 100%%", abundance.Value)
+		}
+
+		var halfLife Optional
+		if value := table.get(row, "half_life"); value != "" {
+			seconds, err := ParseDuration(value)
+			if err != nil {
+				errs.add(row.line, "half_life", "%v", err)
+			} else if seconds <= 0 {
+				errs.add(row.line, "half_life", "value must be positive, got %s", value)
+			}
+			halfLife = Optional{Value: seconds, Valid: true}
+		}
+		if len(errs.Rows) > before {
+			continue
+		}
+		decays := parseDecays(table, row, el.Number, a, errs)
+		if halfLife.Valid != (len(decays) > 0) && len(errs.Rows) == before {
+			errs.add(row.line, "decay", "a nuclide needs both a half-life and a decay, or neither")
+		}
+		if len(errs.Rows) > before {
+			continue
+		}
+
+		key := nuclideName(symbol, a, isomer)
+		if first, dup := seen[key]; dup {
+			errs.add(row.line, "nuclide", "duplicate nuclide %s (first defined on line %d)", key, first)
+			continue
+		}
+		seen[key] = row.line
+
+		nuclides = append(nuclides, Nuclide{
+			Symbol:    symbol,
+			Z:         el.Number,
+			N:         a - el.Number,
+			A:         a,
+			Isomer:    isomer,
+			Mass:      mass.Value,
+			Abundance: abundance,
+			HalfLife:  halfLife,
+			Decays:    decays,
+		})
+	}
+
+	return nuclides, errs.err()
+}
+
+// MergeNuclides adds nuclides to NuclideTable, replacing any with the same name,
+// and returns the names of the replaced ones
+func MergeNuclides(nuclides []Nuclide) []string {
+	var overridden []string
+	for _, n := range nuclides {
+		if _, exists := NuclideTable[n.ToString()]; exists {
+			overridden = append(overridden, n.ToString())
+		}
+		NuclideTable[n.ToString()] = n
+	}
+	return overridden
+}
@@ -194,26 +194,25 @@ func (p *Parser) parseMolecules() ([]Molecule, error) {
 	}
 
 	// Check for repetition like H2 or (H2O)3
+	count := 1
 	if p.token.typ == TOKEN_NUMBER {
-		count, err := p.parseNumber()
+		var err error
+		count, err = p.parseNumber()
 		if err != nil {
 			return molecules, err
 		}
-		for i := 0; i < count; i++ {
-			molecules = append(molecules, molecule)
-		}
-	} else {
-		molecules = append(molecules, molecule)
 	}
 
+	// The charge of a group such as [Fe(CN)6]-4 belongs to the group once, not to each nested group
 	if p.token.typ == TOKEN_PLUS || p.token.typ == TOKEN_MINUS {
 		charge, err := p.parseCharge()
 		if err != nil {
 			return molecules, err
 		}
-		for i := range molecules {
-			molecules[i].Charge = charge
-		}
+		molecule.Charge = charge
+	}
+	for i := 0; i < count; i++ {
+		molecules = append(molecules, molecule)
 	}
 
 	return molecules, nil
@@ -221,7 +220,7 @@ func (p *Parser) parseMolecules() ([]Molecule, error) {
 
 // Parse an element with optional charge
 func (p *Parser) parseElements() ([]Element, error) {
-	element, exists := ElementTable[p.token.value]
+	element, exists := lookupElement(p.token.value)
 	if !exists {
 		element = Element{Symbol: p.token.value} 
 	}
@@ -6,44 +6,45 @@ import (
 
 // QuantumNumbers represents the four quantum numbers of an electron
 type QuantumNumbers struct {
-	n   int     // Principal quantum number
-	l   int     // Azimuthal quantum number
-	m int     // Magnetic quantum number
-	s float64 // Spin quantum number
+	N int     `json:"n"`   // Principal quantum number
+	L int     `json:"l"`   // Azimuthal quantum number
+	M int     `json:"m_l"` // Magnetic quantum number
+	S float64 `json:"m_s"` // Spin quantum number
 }
 
 // ToString returns a string representation of the quantum numbers
 func (q QuantumNumbers) ToString() string {
-	return fmt.Sprintf("n: %d, l: %d, m: %d, s: %f", q.n, q.l, q.m, q.s)
+	return fmt.Sprintf("n: %d, l: %d, m: %d, s: %+.1f", q.N, q.L, q.M, q.S)
 }
 
-// GetQuantumNumbers retrieves the quantum numbers for the electron at the specified index in the configuration
-func GetQuantumNumbers(atomicNumber int, electronIndex int) (QuantumNumbers, error) {
-	configuration := GenerateElectronConfiguration(atomicNumber)
-
-	if electronIndex < 0 || electronIndex >= atomicNumber {
-		return QuantumNumbers{}, fmt.Errorf("electron index out of bounds")
-	}
-
-	electronCount := 0
-
-	for _, subshell := range configuration.Subshells {
+// QuantumNumbers returns the quantum numbers of every electron in the configuration, in the order the subshells are listed.
+// Within a subshell, electrons fill the orbitals singly from m = +l down to m = -l with spin +1/2 before pairing with spin -1/2 (Hund's rule).
+func (ec ElectronConfiguration) QuantumNumbers() []QuantumNumbers {
+	var numbers []QuantumNumbers
+	for _, subshell := range ec.Subshells {
+		n := getPrincipalQuantumNumber(subshell.Orbital)
+		l := getAzimuthalQuantumNumber(subshell.Orbital)
 		for i := 0; i < subshell.Electrons; i++ {
-			if electronCount == electronIndex {
-				n := getPrincipalQuantumNumber(subshell.Orbital)
-				l := getAzimuthalQuantumNumber(subshell.Orbital)
-				m := getMagneticQuantumNumber(l, i)
-				s := getSpinQuantumNumber(i)
-
-				return QuantumNumbers{n, l, m, s}, nil
-			}
-			electronCount++
+			numbers = append(numbers, QuantumNumbers{n, l, getMagneticQuantumNumber(l, i), getSpinQuantumNumber(l, i)})
 		}
 	}
+	return numbers
+}
 
-	return QuantumNumbers{}, fmt.Errorf("quantum numbers not found for electron index %d", electronIndex)
+// GetQuantumNumbers retrieves the quantum numbers for the electron at the specified index in the configuration.
+// The index counts from 0 in filling order, so the first 1s electron is 0.
+func GetQuantumNumbers(atomicNumber int, electronIndex int) (QuantumNumbers, error) {
+	return electronQuantumNumbers(GenerateElectronConfiguration(atomicNumber), electronIndex)
 }
 
+// electronQuantumNumbers returns the quantum numbers of the electron at an index of the configuration
+func electronQuantumNumbers(configuration ElectronConfiguration, electronIndex int) (QuantumNumbers, error) {
+	numbers := configuration.QuantumNumbers()
+	if electronIndex < 0 || electronIndex >= len(numbers) {
+		return QuantumNumbers{}, fmt.Errorf("electron index %d out of bounds, expected 0 to %d", electronIndex, len(numbers)-1)
+	}
+	return numbers[electronIndex], nil
+}
 
 // getPrincipalQuantumNumber returns the principal quantum number for a given orbital
 func getPrincipalQuantumNumber(orbital string) int {
@@ -68,27 +69,17 @@ func getAzimuthalQuantumNumber(orbital string) int {
 	}
 }
 
-// getMagneticQuantumNumber returns the magnetic quantum number for a given l and the electron index
+// getMagneticQuantumNumber returns the magnetic quantum number of the electron at an index of its subshell.
+// Each of the 2l+1 orbitals takes one electron, from m = +l down, before any is paired.
 func getMagneticQuantumNumber(l int, electronIndex int) int {
-	if l == 0 { // s subshell
-		return 0
-	}
-	if l == 1 { // p subshell
-		return (electronIndex % 3) - 1 // -1, 0, +1 for p subshell
-	}
-	if l == 2 { // d subshell
-		return (electronIndex % 5) - 2 // -2, -1, 0, +1, +2 for d subshell
-	}
-	if l == 3 { // f subshell
-		return (electronIndex % 7) - 3 // -3, -2, -1, 0, +1, +2, +3 for f subshell
-	}
-	return 0 // Should not reach here
+	return l - electronIndex%(2*l+1)
 }
 
-// getSpinQuantumNumber returns the spin quantum number for an electron based on its index
-func getSpinQuantumNumber(electronIndex int) float64 {
-	if electronIndex%2 == 0 {
-		return +0.5 // +1/2 spin for even indexed electrons
+// getSpinQuantumNumber returns the spin quantum number of the electron at an index of its subshell:
+// +1/2 while the orbitals are being singly filled, -1/2 for the electrons that pair them
+func getSpinQuantumNumber(l int, electronIndex int) float64 {
+	if electronIndex < 2*l+1 {
+		return +0.5
 	}
-	return -0.5 // -1/2 spin for odd indexed electrons
+	return -0.5
 }
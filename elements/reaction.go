@@ -0,0 +1,332 @@
+package elements
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Masses of the particles of nuclear reactions, in u
+const (
+	ProtonMass   = 1.007276466621
+	NeutronMass  = 1.00866491595
+	AlphaMass    = 4.001506179127
+	ElectronMass = electronMass
+	// MeVPerU is the energy equivalent of one atomic mass unit
+	MeVPerU = 931.49410242
+)
+
+// Particle is a nucleus or an elementary particle in a nuclear equation
+type Particle struct {
+	Name    string  // As it is printed (e.g. "U-238", "α" or "e-")
+	A       int     // Mass number
+	Z       int     // Charge, the atomic number of a nucleus
+	Lepton  int     // Lepton number: +1 for electrons and neutrinos, -1 for their antiparticles
+	Mass    float64 // u, of the bare nucleus or particle; 0 if unknown
+	Nuclide bool    // Whether the particle is a nucleus of the nuclide table
+}
+
+// particles are the light particles of nuclear equations, keyed by the names they may be written as
+var particles = map[string]Particle{}
+
+func init() {
+	for _, p := range []struct {
+		names    []string
+		particle Particle
+	}{
+		{[]string{"alpha", "α"}, Particle{Name: "α", A: 4, Z: 2, Mass: AlphaMass}},
+		{[]string{"e-", "e", "beta", "beta-", "β", "β-", "electron"}, Particle{Name: "e-", Z: -1, Lepton: 1, Mass: ElectronMass}},
+		{[]string{"e+", "beta+", "β+", "positron"}, Particle{Name: "e+", Z: 1, Lepton: -1, Mass: ElectronMass}},
+		{[]string{"n", "neutron"}, Particle{Name: "n", A: 1, Mass: NeutronMass}},
+		{[]string{"p", "proton"}, Particle{Name: "p", A: 1, Z: 1, Mass: ProtonMass}},
+		{[]string{"gamma", "γ"}, Particle{Name: "γ"}},
+		{[]string{"neutrino", "nu", "ν", "νe"}, Particle{Name: "ν", Lepton: 1}},
+		{[]string{"antineutrino", "anti-neutrino", "nubar", "ν̄", "ν̄e"}, Particle{Name: "ν̄", Lepton: -1}},
+	} {
+		for _, name := range p.names {
+			particles[name] = p.particle
+		}
+	}
+}
+
+// ParseParticle parses a particle name (alpha, e-, e+, n, p, gamma, neutrino, antineutrino) or a nuclide
+// such as "U-238". Nuclides need not be in NuclideTable, but their mass is only known if they are.
+func ParseParticle(s string) (Particle, error) {
+	if p, ok := lookupParticle(s); ok {
+		return p, nil
+	}
+	element, a, isomer, err := splitNuclide(s)
+	if err != nil {
+		return Particle{}, fmt.Errorf("%q is not a particle or nuclide", s)
+	}
+	for _, el := range ElementTable {
+		if !strings.EqualFold(el.Symbol, element) && !strings.EqualFold(el.Name, element) {
+			continue
+		}
+		if a < el.Number {
+			return Particle{}, fmt.Errorf("%s has a mass number less than its atomic number %d", s, el.Number)
+		}
+		p := Particle{Name: nuclideName(el.Symbol, a, isomer), A: a, Z: el.Number, Nuclide: true}
+		if n, ok := NuclideTable[p.Name]; ok {
+			p.Mass = n.Mass - float64(n.Z)*electronMass
+		}
+		return p, nil
+	}
+	return Particle{}, fmt.Errorf("unknown element %q in %q", element, s)
+}
+
+// lookupParticle finds a light particle by name. Names of more than two letters may be in any case,
+// so that N and P stay nitrogen and phosphorus rather than a neutron and a proton.
+func lookupParticle(name string) (Particle, bool) {
+	if p, ok := particles[name]; ok {
+		return p, true
+	}
+	if len([]rune(name)) > 2 {
+		p, ok := particles[strings.ToLower(name)]
+		return p, ok
+	}
+	return Particle{}, false
+}
+
+// particleFor returns the particle with the given mass number and charge: a light particle where there is one,
+// otherwise a nucleus
+func particleFor(a, z int) (Particle, bool) {
+	for _, name := range []string{"n", "p", "alpha", "e-", "e+"} {
+		if p := particles[name]; p.A == a && p.Z == z {
+			return p, true
+		}
+	}
+	if a == 0 || z <= 0 || a < z {
+		return Particle{}, false
+	}
+	symbol, ok := symbolOf(z)
+	if !ok {
+		return Particle{}, false
+	}
+	p, err := ParseParticle(nuclideName(symbol, a, false))
+	return p, err == nil
+}
+
+// Term is a particle with its count in a nuclear equation
+type Term struct {
+	Count    int
+	Particle Particle
+}
+
+// NuclearEquation is a nuclear reaction or decay such as U-238 → Th-234 + α
+type NuclearEquation struct {
+	Reactants, Products []Term
+	Deduced             *Term // The particle worked out to balance the equation, if one was missing
+	DeducedReactant     bool  // Whether the deduced particle is a reactant
+}
+
+// IsNuclearEquation reports whether s is written as an equation, with an arrow
+func IsNuclearEquation(s string) bool {
+	for _, arrow := range equationArrows {
+		if strings.Contains(s, arrow) {
+			return true
+		}
+	}
+	return false
+}
+
+// equationArrows are the ways the two sides of an equation may be separated. A bare "=" is left out
+// since it appears in molecule names.
+var equationArrows = []string{"->", "→", "=>"}
+
+// ParseNuclearEquation parses an equation such as "U-238 -> Th-234 + alpha" or "C-14 -> N-14 + e- + antineutrino".
+// Terms are separated by "+" and may have a count (e.g. "3n"). A "?" stands for a missing particle, and if the
+// equation doesn't balance without it, the one particle that balances mass number and charge is deduced.
+func ParseNuclearEquation(s string) (NuclearEquation, error) {
+	var left, right string
+	found := false
+	for _, arrow := range equationArrows {
+		if i := strings.Index(s, arrow); i >= 0 {
+			left, right, found = s[:i], s[i+len(arrow):], true
+			break
+		}
+	}
+	if !found {
+		return NuclearEquation{}, fmt.Errorf("%q has no arrow, write it like U-238 -> Th-234 + alpha", s)
+	}
+
+	var eq NuclearEquation
+	unknowns := 0
+	var err error
+	unknownLeft := false
+	eq.Reactants, err = parseTerms(left, &unknowns, &unknownLeft, true)
+	if err != nil {
+		return NuclearEquation{}, err
+	}
+	eq.Products, err = parseTerms(right, &unknowns, &unknownLeft, false)
+	if err != nil {
+		return NuclearEquation{}, err
+	}
+	if len(eq.Reactants) == 0 && (unknowns == 0 || !unknownLeft) {
+		return NuclearEquation{}, fmt.Errorf("%q has no reactants", s)
+	}
+	if unknowns > 1 {
+		return NuclearEquation{}, fmt.Errorf("%q has more than one missing particle", s)
+	}
+
+	dA, dZ := eq.Imbalance()
+	if dA == 0 && dZ == 0 {
+		if unknowns == 1 {
+			return NuclearEquation{}, fmt.Errorf("%q already balances, so the missing particle is a photon or neutrino", s)
+		}
+		return eq, nil
+	}
+	// The missing particle makes up the difference on its side; without a "?" it is a product
+	if unknownLeft {
+		dA, dZ = -dA, -dZ
+	}
+	p, ok := particleFor(dA, dZ)
+	if !ok {
+		return NuclearEquation{}, fmt.Errorf("%q can't be balanced by one particle: mass number is off by %d and charge by %d", s, dA, dZ)
+	}
+	eq.Deduced = &Term{Count: 1, Particle: p}
+	eq.DeducedReactant = unknownLeft
+	if unknownLeft {
+		eq.Reactants = append(eq.Reactants, *eq.Deduced)
+	} else {
+		eq.Products = append(eq.Products, *eq.Deduced)
+	}
+	return eq, nil
+}
+
+// parseTerms parses one side of an equation, counting "?" placeholders in unknowns
+func parseTerms(side string, unknowns *int, unknownLeft *bool, left bool) ([]Term, error) {
+	var terms []Term
+	for _, text := range splitTerms(side) {
+		if text == "?" {
+			*unknowns++
+			*unknownLeft = left
+			continue
+		}
+		term, err := parseTerm(text)
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+	}
+	return terms, nil
+}
+
+// splitTerms splits a side of an equation at its "+" signs. A "+" right after a letter and before a space,
+// another "+" or the end is the charge of a positron (e.g. "e+" or "beta+") rather than a separator.
+func splitTerms(side string) []string {
+	var terms []string
+	runes := []rune(side)
+	start := 0
+	for i, r := range runes {
+		if r != '+' {
+			continue
+		}
+		sign := i > 0 && unicode.IsLetter(runes[i-1]) && (i+1 == len(runes) || unicode.IsSpace(runes[i+1]) || runes[i+1] == '+')
+		if sign {
+			continue
+		}
+		terms = append(terms, string(runes[start:i]))
+		start = i + 1
+	}
+	terms = append(terms, string(runes[start:]))
+
+	var trimmed []string
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			trimmed = append(trimmed, term)
+		}
+	}
+	return trimmed
+}
+
+// parseTerm parses a particle with an optional count, as in "3n" or "2 alpha". A number before an element
+// is its mass number instead (e.g. "238U").
+func parseTerm(text string) (Term, error) {
+	i := strings.IndexFunc(text, func(r rune) bool { return !unicode.IsDigit(r) })
+	if i > 0 {
+		rest := strings.TrimSpace(text[i:])
+		if _, ok := lookupParticle(rest); ok || text[i] == ' ' {
+			count, _ := strconv.Atoi(text[:i])
+			p, err := ParseParticle(rest)
+			if err != nil {
+				return Term{}, err
+			}
+			if count == 0 {
+				return Term{}, fmt.Errorf("%q has a count of 0", text)
+			}
+			return Term{Count: count, Particle: p}, nil
+		}
+	}
+	p, err := ParseParticle(text)
+	if err != nil {
+		return Term{}, err
+	}
+	return Term{Count: 1, Particle: p}, nil
+}
+
+// sum returns the total mass number, charge and lepton number of terms
+func sum(terms []Term) (a, z, lepton int) {
+	for _, term := range terms {
+		a += term.Count * term.Particle.A
+		z += term.Count * term.Particle.Z
+		lepton += term.Count * term.Particle.Lepton
+	}
+	return a, z, lepton
+}
+
+// Imbalance returns how much the reactants' mass number and charge exceed the products'
+func (eq NuclearEquation) Imbalance() (dA, dZ int) {
+	aIn, zIn, _ := sum(eq.Reactants)
+	aOut, zOut, _ := sum(eq.Products)
+	return aIn - aOut, zIn - zOut
+}
+
+// LeptonImbalance returns how much the reactants' lepton number exceeds the products', which is non-zero when
+// a neutrino or antineutrino has been left out (e.g. of a beta decay)
+func (eq NuclearEquation) LeptonImbalance() int {
+	_, _, in := sum(eq.Reactants)
+	_, _, out := sum(eq.Products)
+	return in - out
+}
+
+// Q returns the energy released in MeV from the masses of the nuclei and particles, and false if a nucleus
+// has no mass in NuclideTable
+func (eq NuclearEquation) Q() (float64, bool) {
+	mass := func(terms []Term) (float64, bool) {
+		total := 0.0
+		for _, term := range terms {
+			if term.Particle.Nuclide && term.Particle.Mass == 0 {
+				return 0, false
+			}
+			total += float64(term.Count) * term.Particle.Mass
+		}
+		return total, true
+	}
+	in, ok := mass(eq.Reactants)
+	if !ok {
+		return 0, false
+	}
+	out, ok := mass(eq.Products)
+	if !ok {
+		return 0, false
+	}
+	return (in - out) * MeVPerU, true
+}
+
+// ToString returns the equation with an arrow (e.g. "U-238 → Th-234 + α")
+func (eq NuclearEquation) ToString() string {
+	side := func(terms []Term) string {
+		var parts []string
+		for _, term := range terms {
+			if term.Count == 1 {
+				parts = append(parts, term.Particle.Name)
+			} else {
+				parts = append(parts, fmt.Sprintf("%d%s", term.Count, term.Particle.Name))
+			}
+		}
+		return strings.Join(parts, " + ")
+	}
+	return side(eq.Reactants) + " → " + side(eq.Products)
+}
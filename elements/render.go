@@ -0,0 +1,76 @@
+package elements
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-colorable"
+	"github.com/mattn/go-isatty"
+)
+
+// Style selects whether a Renderer uses colours
+type Style int
+
+const (
+	StyleAuto  Style = iota // Colour only on a terminal, and only if NO_COLOR is unset
+	StyleColor              // Always colour
+	StylePlain              // Never colour
+)
+
+// ParseStyle parses a style name: "auto", "color" or "plain"
+func ParseStyle(name string) (Style, error) {
+	switch name {
+	case "auto", "":
+		return StyleAuto, nil
+	case "color", "colour":
+		return StyleColor, nil
+	case "plain":
+		return StylePlain, nil
+	}
+	return StyleAuto, fmt.Errorf("unknown style %q, expected auto, color or plain", name)
+}
+
+// Renderer draws periodic tables and reports to a writer
+type Renderer struct {
+	Out   io.Writer
+	Color bool       // Emit colour escape sequences
+	Depth ColorDepth // Colours available when Color is set
+	Width int        // Columns to fit tables in, 0 for no limit
+}
+
+// NewRenderer returns a renderer writing to w in the given style.
+// When w is a terminal its width is used to fit tables, and colour output goes through
+// go-colorable so that escape sequences also work on older Windows consoles.
+func NewRenderer(w io.Writer, style Style) *Renderer {
+	r := &Renderer{Out: w}
+
+	f, isFile := w.(*os.File)
+	tty := isFile && (isatty.IsTerminal(f.Fd()) || isatty.IsCygwinTerminal(f.Fd()))
+
+	switch style {
+	case StyleColor:
+		r.Color = true
+	case StyleAuto:
+		r.Color = tty && os.Getenv("NO_COLOR") == ""
+	}
+	if r.Color {
+		r.Depth = DetectColorDepth()
+		if tty {
+			r.Out = colorable.NewColorable(f)
+		}
+	}
+
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		r.Width = columns
+	} else if tty {
+		r.Width = terminalWidth(f)
+	}
+	return r
+}
+
+// DrawPeriodicTable draws the periodic table to stdout, see Renderer.PeriodicTable
+func DrawPeriodicTable(molecule Molecule, opts TableOptions) error {
+	return NewRenderer(os.Stdout, StyleAuto).PeriodicTable(molecule, opts)
+}
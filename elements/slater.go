@@ -0,0 +1,141 @@
+package elements
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ShieldingGroup is one of Slater's electron groups and its contribution to the shielding of an electron
+type ShieldingGroup struct {
+	Group     string  // e.g. "2s2p" or "3d"
+	Electrons int     // Electrons in the group, not counting the shielded electron
+	Factor    float64 // Shielding per electron
+}
+
+// Shielding is the effective nuclear charge on an electron by Slater's rules
+type Shielding struct {
+	Orbital   string
+	Z         int
+	Groups    []ShieldingGroup // Groups inside and including the electron's own, innermost first
+	Constant  float64          // Total shielding S
+	Effective float64          // Z_eff = Z - S
+}
+
+// ToString returns the calculation with its breakdown (e.g., "Z_eff(3s) = 11 - 8.80 = 2.20 (1s 2×1.00, 2s2p 8×0.85)")
+func (s Shielding) ToString() string {
+	var groups []string
+	for _, g := range s.Groups {
+		if g.Electrons > 0 {
+			groups = append(groups, fmt.Sprintf("%s %d×%.2f", g.Group, g.Electrons, g.Factor))
+		}
+	}
+	str := fmt.Sprintf("Z_eff(%s) = %d - %.2f = %.2f", s.Orbital, s.Z, s.Constant, s.Effective)
+	if len(groups) > 0 {
+		str += " (" + strings.Join(groups, ", ") + ")"
+	}
+	return str
+}
+
+// slaterGroup returns Slater's group of an orbital: s and p of a shell share a group, d and f are groups of their own
+func slaterGroup(orbital string) string {
+	n := getPrincipalQuantumNumber(orbital)
+	if n > 1 && getAzimuthalQuantumNumber(orbital) <= 1 {
+		return fmt.Sprintf("%ds%dp", n, n)
+	}
+	return orbital
+}
+
+// slaterGroups lists Slater's groups innermost first
+var slaterGroups = []string{"1s", "2s2p", "3s3p", "3d", "4s4p", "4d", "4f", "5s5p", "5d", "5f", "6s6p", "6d", "7s7p"}
+
+// EffectiveNuclearCharge returns the effective nuclear charge on an electron in an occupied orbital of the element
+// or its ion by Slater's rules. Electrons in the same group shield 0.35 each (0.30 in 1s). For an s or p electron,
+// electrons in shell n-1 shield 0.85 and deeper ones 1.00; for a d or f electron, every group inside shields 1.00.
+func (el Element) EffectiveNuclearCharge(orbital string) (Shielding, error) {
+	configuration := el.GetElectronConfiguration()
+	if configuration.count(orbital) == 0 {
+		return Shielding{}, fmt.Errorf("%s has no %s electrons", el.ToString(), orbital)
+	}
+	return configuration.shielding(el.Number, orbital), nil
+}
+
+// shielding applies Slater's rules to an electron in an occupied orbital of the configuration, around a nucleus of charge z
+func (ec ElectronConfiguration) shielding(z int, orbital string) Shielding {
+	own := slaterGroup(orbital)
+	n := getPrincipalQuantumNumber(orbital)
+	sp := getAzimuthalQuantumNumber(orbital) <= 1
+
+	electrons := make(map[string]int)
+	for _, subshell := range ec.Subshells {
+		electrons[slaterGroup(subshell.Orbital)] += subshell.Electrons
+	}
+
+	shielding := Shielding{Orbital: orbital, Z: z}
+	for _, group := range slaterGroups {
+		g := ShieldingGroup{Group: group, Electrons: electrons[group]}
+		if group == own {
+			g.Electrons--
+			g.Factor = 0.35
+			if group == "1s" {
+				g.Factor = 0.30
+			}
+		} else if sp && getPrincipalQuantumNumber(group) == n-1 {
+			g.Factor = 0.85
+		} else {
+			g.Factor = 1.00
+		}
+		shielding.Groups = append(shielding.Groups, g)
+		shielding.Constant += float64(g.Electrons) * g.Factor
+		if group == own {
+			break
+		}
+	}
+	shielding.Effective = float64(z) - shielding.Constant
+	return shielding
+}
+
+// slaterN are Slater's effective principal quantum numbers n* by n. He gave none beyond n = 6, so 7 reuses it.
+var slaterN = []float64{0, 1, 2, 3, 3.7, 4.0, 4.2, 4.2}
+
+// orbitalEnergy estimates the energy in eV of an electron in an occupied orbital of the configuration
+// as -13.6 (Z_eff / n*)², using Slater's rules
+func (ec ElectronConfiguration) orbitalEnergy(z int, orbital string) float64 {
+	nStar := slaterN[getPrincipalQuantumNumber(orbital)]
+	zEff := ec.shielding(z, orbital).Effective
+	return -13.6 * (zEff / nStar) * (zEff / nStar)
+}
+
+// OutermostSubshell returns the occupied subshell with the highest n, and the highest l within that shell.
+// It is the valence subshell that a cation loses electrons from first.
+func (ec ElectronConfiguration) OutermostSubshell() (Subshell, bool) {
+	outer := -1
+	for i, subshell := range ec.Subshells {
+		if subshell.Electrons == 0 {
+			continue
+		}
+		if outer < 0 {
+			outer = i
+			continue
+		}
+		n, l := getPrincipalQuantumNumber(subshell.Orbital), getAzimuthalQuantumNumber(subshell.Orbital)
+		outerN, outerL := getPrincipalQuantumNumber(ec.Subshells[outer].Orbital), getAzimuthalQuantumNumber(ec.Subshells[outer].Orbital)
+		if n > outerN || (n == outerN && l > outerL) {
+			outer = i
+		}
+	}
+	if outer < 0 {
+		return Subshell{}, false
+	}
+	return ec.Subshells[outer], true
+}
+
+// slaterEnergy estimates the total electronic energy in eV of the configuration as the sum of its orbital energies
+func (ec ElectronConfiguration) slaterEnergy(z int) float64 {
+	total := 0.0
+	for _, subshell := range ec.Subshells {
+		if subshell.Electrons > 0 {
+			total += float64(subshell.Electrons) * ec.orbitalEnergy(z, subshell.Orbital)
+		}
+	}
+	return total
+}
@@ -0,0 +1,177 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	rydbergInfinity = 10973731.568160  // Rydberg constant for an infinitely heavy nucleus, 1/m
+	rydbergEnergy   = 13.605693122994  // R∞hc, eV
+	electronMass    = 5.48579909065e-4 // u
+	speedOfLight    = 299792458        // m/s
+	planckEV        = 4.135667696e-15  // eV·s
+)
+
+// VisibleMin and VisibleMax are the wavelengths in nm of the visible spectrum
+const (
+	VisibleMin = 380.0
+	VisibleMax = 750.0
+)
+
+// SpectralSeries maps the names of the hydrogen series to their lower level
+var SpectralSeries = map[string]int{
+	"lyman":     1,
+	"balmer":    2,
+	"paschen":   3,
+	"brackett":  4,
+	"pfund":     5,
+	"humphreys": 6,
+}
+
+// Transition is a line of a hydrogen-like spectrum, from an upper level to a lower one
+type Transition struct {
+	Upper, Lower int
+	Wavelength   float64 // nm, in vacuum
+	Frequency    float64 // Hz
+	Energy       float64 // eV of the emitted photon
+}
+
+// RydbergConstant returns the Rydberg constant in 1/m for the element's nucleus, corrected for its reduced mass
+func (el Element) RydbergConstant() float64 {
+	nucleus := el.Amu - float64(el.Number)*electronMass
+	if nucleus <= 0 {
+		return rydbergInfinity
+	}
+	return rydbergInfinity / (1 + electronMass/nucleus)
+}
+
+// EnergyLevel returns the energy in eV of level n of the element's hydrogen-like ion, which has a single electron
+func (el Element) EnergyLevel(n int) float64 {
+	z := float64(el.Number)
+	return -rydbergEnergy * el.RydbergConstant() / rydbergInfinity * z * z / float64(n*n)
+}
+
+// HydrogenLikeTransition returns the line from level upper to level lower of the element's hydrogen-like ion
+// by the Rydberg formula 1/λ = R_M Z² (1/lower² - 1/upper²)
+func (el Element) HydrogenLikeTransition(upper, lower int) (Transition, error) {
+	if lower < 1 || upper <= lower {
+		return Transition{}, fmt.Errorf("invalid transition %d → %d, the upper level must be above the lower one", upper, lower)
+	}
+	z := float64(el.Number)
+	waveNumber := el.RydbergConstant() * z * z * (1/float64(lower*lower) - 1/float64(upper*upper))
+	return Transition{
+		Upper:      upper,
+		Lower:      lower,
+		Wavelength: 1e9 / waveNumber,
+		Frequency:  speedOfLight * waveNumber,
+		Energy:     planckEV * speedOfLight * waveNumber,
+	}, nil
+}
+
+// HydrogenLikeSeries returns the lines ending on the lower level from every upper level up to maxUpper,
+// longest wavelength first
+func (el Element) HydrogenLikeSeries(lower, maxUpper int) ([]Transition, error) {
+	var lines []Transition
+	for upper := lower + 1; upper <= maxUpper; upper++ {
+		line, err := el.HydrogenLikeTransition(upper, lower)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// SeriesLimit returns the wavelength in nm that the lines ending on the lower level converge to
+func (el Element) SeriesLimit(lower int) float64 {
+	z := float64(el.Number)
+	return 1e9 / (el.RydbergConstant() * z * z / float64(lower*lower))
+}
+
+// wavelengthColour returns an approximate display colour of visible light, and false outside the visible spectrum
+func wavelengthColour(nm float64) (rgb, bool) {
+	if nm < VisibleMin || nm > VisibleMax {
+		return rgb{}, false
+	}
+	var r, g, b float64
+	switch {
+	case nm < 440:
+		r, b = (440-nm)/(440-VisibleMin), 1
+	case nm < 490:
+		g, b = (nm-440)/50, 1
+	case nm < 510:
+		g, b = 1, (510-nm)/20
+	case nm < 580:
+		r, g = (nm-510)/70, 1
+	case nm < 645:
+		r, g = 1, (645-nm)/65
+	default:
+		r = 1
+	}
+
+	// Dim the ends, where the eye is less sensitive
+	intensity := 1.0
+	if nm < 420 {
+		intensity = 0.3 + 0.7*(nm-VisibleMin)/(420-VisibleMin)
+	} else if nm > 700 {
+		intensity = 0.3 + 0.7*(VisibleMax-nm)/(VisibleMax-700)
+	}
+	level := func(v float64) uint8 {
+		return uint8(math.Round(255 * math.Pow(v*intensity, 0.8)))
+	}
+	return rgb{level(r), level(g), level(b)}, true
+}
+
+// SpectrumBand draws the visible spectrum from VisibleMin to VisibleMax with a mark at each line, the strongest
+// line winning where several share a column. Coloured output shows each line in its colour on black, dimmed by
+// its intensity relative to the strongest line; plain output marks lines with "|" on "-".
+func (r *Renderer) SpectrumBand(lines []EmissionLine) {
+	width := 72
+	if r.Width > 0 && r.Width-20 < width {
+		width = max(r.Width-20, 10)
+	}
+
+	strongest := 0.0
+	for _, line := range lines {
+		strongest = math.Max(strongest, line.Intensity)
+	}
+	marks := make([]*EmissionLine, width)
+	for i, line := range lines {
+		if line.Wavelength < VisibleMin || line.Wavelength > VisibleMax {
+			continue
+		}
+		column := int((line.Wavelength-VisibleMin)/(VisibleMax-VisibleMin)*float64(width-1) + 0.5)
+		if marks[column] == nil || line.Intensity > marks[column].Intensity {
+			marks[column] = &lines[i]
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("  %g nm ", VisibleMin))
+	for _, line := range marks {
+		switch {
+		case line == nil && r.Color:
+			sb.WriteString(rgb{0, 0, 0}.background(r.Depth) + " ")
+		case line == nil:
+			sb.WriteString("-")
+		case r.Color:
+			colour, _ := wavelengthColour(line.Wavelength)
+			// Weak lines fade towards black, but stay visible
+			brightness := 1.0
+			if strongest > 0 {
+				brightness = 0.25 + 0.75*math.Sqrt(line.Intensity/strongest)
+			}
+			dim := func(v uint8) uint8 { return uint8(math.Round(float64(v) * brightness)) }
+			sb.WriteString(rgb{dim(colour.r), dim(colour.g), dim(colour.b)}.background(r.Depth) + " ")
+		default:
+			sb.WriteString("|")
+		}
+	}
+	if r.Color {
+		sb.WriteString("\x1b[0m")
+	}
+	sb.WriteString(fmt.Sprintf(" %g nm", VisibleMax))
+	fmt.Fprintln(r.Out, sb.String())
+}
@@ -0,0 +1,137 @@
+package elements
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// Measurements of the SVG periodic table, in pixels
+const (
+	svgTile   = 58 // Side of a tile
+	svgPitch  = 60 // Distance between neighbouring tiles
+	svgMargin = 20
+	svgGap    = 20 // Extra space above the f-block rows
+)
+
+// hex returns the colour in #rrggbb notation
+func (c rgb) hex() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+}
+
+// highlightFill is the fill of formula elements on an uncoloured table, the green of the terminal highlight
+var highlightFill = rgb{165, 214, 167}
+
+// WriteSVG writes the periodic table as a standalone SVG image. It matches the tiled terminal
+// rendering: cells are coloured by opts.ColorBy with a legend, and elements in the molecule
+// are outlined, or filled green when the table isn't coloured.
+func WriteSVG(w io.Writer, molecule Molecule, opts TableOptions) error {
+	colouring, err := newColouring(opts.ColorBy, TrueColor)
+	if err != nil {
+		return err
+	}
+	grid := buildGrid(opts.Long)
+	highlighted := highlights(molecule)
+
+	// Lay out the rows, leaving room above the f-block
+	cols := len(grid[0].cells)
+	tops := make([]int, len(grid))
+	y := svgMargin
+	for i, row := range grid {
+		if row.gap {
+			y += svgGap
+		}
+		tops[i] = y
+		y += svgPitch
+	}
+	width := 2*svgMargin + cols*svgPitch
+
+	var body strings.Builder
+	for i, row := range grid {
+		for col, c := range row.cells {
+			if !c.boxed() {
+				continue
+			}
+			writeSVGTile(&body, c, svgMargin+col*svgPitch, tops[i], colouring, highlighted[c.el.Symbol])
+		}
+	}
+	y += writeSVGLegend(&body, colouring, svgMargin, y+svgMargin, width-2*svgMargin)
+	height := y + svgMargin
+
+	fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\" font-family=\"Helvetica, Arial, sans-serif\">\n", width, height, width, height)
+	fmt.Fprintf(w, "<rect width=\"%d\" height=\"%d\" fill=\"#ffffff\"/>\n", width, height)
+	io.WriteString(w, body.String())
+	_, err = io.WriteString(w, "</svg>\n")
+	return err
+}
+
+// writeSVGTile draws one tile with its top-left corner at x, y
+func writeSVGTile(sb *strings.Builder, c cell, x, y int, colouring *colouring, highlighted bool) {
+	fill, stroke, strokeWidth := rgb{255, 255, 255}, "#9e9e9e", 1
+	if c.el.Symbol != "" && colouring != nil {
+		fill = colouring.cell(c.el)
+	}
+	if highlighted {
+		stroke, strokeWidth = "#000000", 3
+		if colouring == nil {
+			fill = highlightFill
+		}
+	}
+	text := "#000000"
+	if !fill.light() {
+		text = "#ffffff"
+	}
+
+	fmt.Fprintf(sb, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" rx=\"3\" fill=\"%s\" stroke=\"%s\" stroke-width=\"%d\"/>\n",
+		x, y, svgTile, svgTile, fill.hex(), stroke, strokeWidth)
+
+	lines := tileLines(c)
+	mid := x + svgTile/2
+	if c.el.Symbol == "" {
+		fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"11\" text-anchor=\"middle\" fill=\"%s\">%s</text>\n", mid, y+24, text, html.EscapeString(lines[0]))
+		fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"11\" text-anchor=\"middle\" fill=\"%s\">%s</text>\n", mid, y+40, text, html.EscapeString(lines[1]))
+		return
+	}
+
+	weight := "normal"
+	if highlighted {
+		weight = "bold"
+	}
+	fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"10\" fill=\"%s\">%d</text>\n", x+4, y+13, text, c.el.Number)
+	fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"20\" font-weight=\"%s\" text-anchor=\"middle\" fill=\"%s\">%s</text>\n",
+		mid, y+36, weight, text, html.EscapeString(c.el.Symbol))
+	fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"9\" text-anchor=\"middle\" fill=\"%s\">%s</text>\n", mid, y+52, text, formatMass(c.el.Amu))
+}
+
+// writeSVGLegend draws the legend of a colouring at x, y and returns its height
+func writeSVGLegend(sb *strings.Builder, colouring *colouring, x, y, width int) int {
+	if colouring == nil {
+		return 0
+	}
+
+	if colouring.title != "" {
+		sb.WriteString("<defs><linearGradient id=\"scale\">")
+		for i, stop := range gradientStops {
+			fmt.Fprintf(sb, "<stop offset=\"%g\" stop-color=\"%s\"/>", float64(i)/float64(len(gradientStops)-1), stop.hex())
+		}
+		sb.WriteString("</linearGradient></defs>\n")
+
+		barWidth := width / 2
+		fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"13\">%s</text>\n", x, y+12, html.EscapeString(colouring.title))
+		fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"11\">%g</text>\n", x, y+36, colouring.lo)
+		fmt.Fprintf(sb, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"14\" fill=\"url(#scale)\"/>\n", x+40, y+24, barWidth)
+		fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"11\">%g</text>\n", x+48+barWidth, y+36, colouring.hi)
+		fmt.Fprintf(sb, "<rect x=\"%d\" y=\"%d\" width=\"14\" height=\"14\" fill=\"%s\"/>\n", x+120+barWidth, y+24, noData.hex())
+		fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"11\">no data</text>\n", x+140+barWidth, y+36)
+		return 40
+	}
+
+	const perRow, columnWidth, rowHeight = 4, 200, 20
+	for i, e := range colouring.entries {
+		ex, ey := x+(i%perRow)*columnWidth, y+(i/perRow)*rowHeight
+		fmt.Fprintf(sb, "<rect x=\"%d\" y=\"%d\" width=\"14\" height=\"14\" fill=\"%s\" stroke=\"#9e9e9e\"/>\n", ex, ey, e.colour.hex())
+		fmt.Fprintf(sb, "<text x=\"%d\" y=\"%d\" font-size=\"12\">%s</text>\n", ex+20, ey+12, html.EscapeString(e.label))
+	}
+	return (len(colouring.entries) + perRow - 1) / perRow * rowHeight
+}
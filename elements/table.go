@@ -0,0 +1,296 @@
+package elements
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// TableOptions controls the layout of the periodic table and how its cells are coloured
+type TableOptions struct {
+	ColorBy string // One of ColorProperties, or empty for the plain table
+	Tiles   bool   // Draw box tiles with atomic number, symbol and mass
+	Long    bool   // Use the 32-column form with the f-block inline
+}
+
+// ColorProperties lists the values accepted by TableOptions.ColorBy
+var ColorProperties = []string{"electronegativity", "radius", "ie1", "amu", "density", "category", "colour"}
+
+// heatmapProperty is a numeric element property that can be drawn as a heatmap
+type heatmapProperty struct {
+	label string
+	value func(el Element) Optional
+}
+
+var heatmapProperties = map[string]heatmapProperty{
+	"electronegativity": {"Electronegativity (Pauling)", func(el Element) Optional { return el.Electronegativity }},
+	"radius":            {"Covalent radius (pm)", func(el Element) Optional { return el.CovalentRadius }},
+	"ie1":               {"First ionization energy (kJ/mol)", func(el Element) Optional { return el.IonizationEnergy[0] }},
+	"amu":               {"Atomic mass (u)", func(el Element) Optional { return Optional{Value: el.Amu, Valid: el.Amu > 0} }},
+	"density":           {"Density (g/cm³)", func(el Element) Optional { return el.Density }},
+}
+
+// categoryColours are the cell colours of the category mode, in legend order.
+// basic is the terminal colour used instead on 16-colour terminals, where the pastels would all look grey.
+var categoryColours = []struct {
+	category string
+	colour   rgb
+	basic    int
+}{
+	{"Alkali Metal", rgb{229, 115, 115}, 9},
+	{"Alkaline Earth Metal", rgb{255, 167, 38}, 3},
+	{"Transition Metal", rgb{255, 238, 88}, 11},
+	{"Post-transition Metal", rgb{144, 164, 174}, 4},
+	{"Metal", rgb{176, 190, 197}, 7},
+	{"Metalloid", rgb{129, 199, 132}, 2},
+	{"Nonmetal", rgb{100, 181, 246}, 12},
+	{"Halogen", rgb{77, 208, 225}, 14},
+	{"Noble Gas", rgb{186, 104, 200}, 5},
+	{"Lanthanide", rgb{240, 98, 146}, 13},
+	{"Actinide", rgb{255, 138, 101}, 1},
+	{"Transactinide", rgb{161, 136, 127}, 6},
+}
+
+// colourFamilies names the element families of the colour column, which holds basic terminal colour indices
+var colourFamilies = map[int]string{
+	0:  "Boron group",
+	1:  "Hydrogen",
+	2:  "Noble gases",
+	3:  "Halogens",
+	4:  "Chalcogens",
+	5:  "Pnictogens",
+	6:  "Carbon group",
+	7:  "Transition metals",
+	10: "Alkaline earth metals",
+	11: "Alkali metals",
+	12: "Lanthanides",
+	13: "Actinides",
+}
+
+// legendEntry is one swatch of a categorical legend
+type legendEntry struct {
+	label  string
+	colour rgb
+}
+
+// colouring assigns cell colours for one of the ColorProperties, along with its legend
+type colouring struct {
+	cell    func(el Element) rgb
+	title   string        // Label of a heatmap scale, empty for categorical colourings
+	lo, hi  float64       // Range of a heatmap scale
+	entries []legendEntry // Swatches of a categorical legend
+}
+
+// newColouring returns the colouring for a ColorProperties name, or nil for an empty name.
+// depth picks the palette, since pastel categories are indistinct on 16-colour terminals.
+func newColouring(colorBy string, depth ColorDepth) (*colouring, error) {
+	if colorBy == "" {
+		return nil, nil
+	}
+	if colorBy == "category" {
+		return categories(depth), nil
+	}
+	if colorBy == "colour" {
+		return families(), nil
+	}
+	if prop, ok := heatmapProperties[colorBy]; ok {
+		return heatmap(prop), nil
+	}
+	return nil, fmt.Errorf("unknown property %q, expected one of: %s", colorBy, strings.Join(ColorProperties, ", "))
+}
+
+// legend returns the terminal legend: a gradient bar for heatmaps, or swatches four to a row
+func (c *colouring) legend(depth ColorDepth) string {
+	var sb strings.Builder
+	if c.title != "" {
+		sb.WriteString(fmt.Sprintf("  %s: %g ", c.title, c.lo))
+		const width = 32
+		for i := 0; i < width; i++ {
+			sb.WriteString(gradient(float64(i)/(width-1)).background(depth) + " \x1b[0m")
+		}
+		sb.WriteString(fmt.Sprintf(" %g   %s    \x1b[0m no data", c.hi, noData.background(depth)))
+		return sb.String()
+	}
+	for i, e := range c.entries {
+		if i > 0 && i%4 == 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(fmt.Sprintf("  %s    \x1b[0m %-22s", e.colour.background(depth), e.label))
+	}
+	return sb.String()
+}
+
+// heatmap colours cells on a gradient by a numeric property scaled over ElementTable
+func heatmap(prop heatmapProperty) *colouring {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, el := range ElementTable {
+		if v := prop.value(el); v.Valid {
+			lo, hi = math.Min(lo, v.Value), math.Max(hi, v.Value)
+		}
+	}
+
+	cell := func(el Element) rgb {
+		v := prop.value(el)
+		if !v.Valid {
+			return noData
+		}
+		if hi == lo {
+			return gradient(0)
+		}
+		return gradient((v.Value - lo) / (hi - lo))
+	}
+
+	return &colouring{cell: cell, title: prop.label, lo: lo, hi: hi}
+}
+
+// categories colours cells by category.
+// Categories without a colour of their own, e.g. from user data, are drawn grey.
+func categories(depth ColorDepth) *colouring {
+	colours := make(map[string]rgb)
+	for _, c := range categoryColours {
+		if depth == Colors16 {
+			colours[c.category] = ansiColours[c.basic]
+		} else {
+			colours[c.category] = c.colour
+		}
+	}
+
+	cell := func(el Element) rgb {
+		c, ok := colours[el.Category]
+		if !ok {
+			return noData
+		}
+		return c
+	}
+
+	present := make(map[string]bool)
+	for _, el := range ElementTable {
+		present[el.Category] = true
+	}
+	var entries []legendEntry
+	for _, c := range categoryColours {
+		if present[c.category] {
+			entries = append(entries, legendEntry{c.category, colours[c.category]})
+		}
+		delete(present, c.category)
+	}
+	if len(present) > 0 {
+		entries = append(entries, legendEntry{"Other", noData})
+	}
+
+	return &colouring{cell: cell, entries: entries}
+}
+
+// families colours cells by the colour column.
+// On a 16-colour terminal each index is drawn as that exact terminal colour.
+func families() *colouring {
+	index := func(el Element) (int, bool) {
+		i, err := strconv.Atoi(el.Colour)
+		return i, err == nil && i >= 0 && i < len(ansiColours)
+	}
+
+	cell := func(el Element) rgb {
+		i, ok := index(el)
+		if !ok {
+			return noData
+		}
+		return ansiColours[i]
+	}
+
+	present := make(map[int]bool)
+	for _, el := range ElementTable {
+		if i, ok := index(el); ok {
+			present[i] = true
+		}
+	}
+	var entries []legendEntry
+	for i, c := range ansiColours {
+		if !present[i] {
+			continue
+		}
+		label, ok := colourFamilies[i]
+		if !ok {
+			label = fmt.Sprintf("Colour %d", i)
+		}
+		entries = append(entries, legendEntry{label, c})
+	}
+
+	return &colouring{cell: cell, entries: entries}
+}
+
+// pickLayout returns the grid of the widest layout the options allow that fits in width columns,
+// and whether it is drawn as tiles
+func pickLayout(opts TableOptions, width int) ([]gridRow, bool) {
+	type layout struct{ tiles, long bool }
+	var grid []gridRow
+	var tiles bool
+	for _, l := range []layout{{true, true}, {true, false}, {false, true}, {false, false}} {
+		if (l.tiles && !opts.Tiles) || (l.long && !opts.Long) {
+			continue
+		}
+		grid, tiles = buildGrid(l.long), l.tiles
+		if width <= 0 || gridWidth(grid, tiles) <= width {
+			break
+		}
+	}
+	return grid, tiles
+}
+
+// highlights returns the set of element symbols in the molecule
+func highlights(molecule Molecule) map[string]bool {
+	highlighted := make(map[string]bool)
+	for _, el := range molecule.Elements {
+		highlighted[el.Symbol] = true
+	}
+	return highlighted
+}
+
+// PeriodicTable highlights elements in the molecule, optionally colouring every cell by a property.
+// The widest layout the options allow that fits in r.Width is used. Without colours, highlighted
+// elements are bracketed, and asking for a colouring is an error rather than drawing the table uncoloured.
+func (r *Renderer) PeriodicTable(molecule Molecule, opts TableOptions) error {
+	colouring, err := newColouring(opts.ColorBy, r.Depth)
+	if err != nil {
+		return err
+	}
+	if colouring != nil && !r.Color {
+		return fmt.Errorf("colouring by %s needs colour output, use -style color or -format svg", opts.ColorBy)
+	}
+
+	grid, tiles := pickLayout(opts, r.Width)
+	highlighted := highlights(molecule)
+
+	// decorate styles one line of an element's cell, already padded to the cell width.
+	// On a coloured table the highlight is bold and underlined on the symbol so the cell keeps its colour.
+	decorate := func(el Element, line int, text string) string {
+		switch {
+		case !r.Color && highlighted[el.Symbol] && line == 1 && tiles:
+			return fmt.Sprintf("%-*s", tileWidth, " ["+el.Symbol+"]")
+		case !r.Color && highlighted[el.Symbol] && line == 1:
+			return fmt.Sprintf("%*s", compactWidth, "["+el.Symbol+"]")
+		case colouring != nil && highlighted[el.Symbol] && line == 1:
+			return colouring.cell(el).background(r.Depth) + "\x1b[1;4m" + text + "\x1b[0m"
+		case colouring != nil:
+			return colouring.cell(el).background(r.Depth) + text + "\x1b[0m"
+		case r.Color && highlighted[el.Symbol]:
+			return "\x1b[0;92m" + text + "\x1b[0m"
+		}
+		return text
+	}
+
+	if tiles {
+		drawTiles(r.Out, grid, decorate)
+	} else {
+		drawCompact(r.Out, grid, decorate)
+	}
+
+	if colouring != nil {
+		fmt.Fprintln(r.Out)
+		fmt.Fprintln(r.Out, colouring.legend(r.Depth))
+		if len(highlighted) > 0 {
+			fmt.Fprintln(r.Out, "  \x1b[1;4mBold underlined\x1b[0m elements are in the formula")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package elements
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TermSymbol is a Russell–Saunders term ²ˢ⁺¹L_J. Spins are stored doubled so half-integers stay exact.
+type TermSymbol struct {
+	TwoS int // 2S, one less than the multiplicity
+	L    int // Total orbital angular momentum
+	TwoJ int // 2J
+}
+
+// orbitalLetters are the letters of L = 0, 1, 2, ... (J is skipped)
+const orbitalLetters = "SPDFGHIKLMNOQRTUV"
+
+var superscripts = strings.NewReplacer("0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴", "5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹")
+var subscripts = strings.NewReplacer("0", "₀", "1", "₁", "2", "₂", "3", "₃", "4", "₄", "5", "₅", "6", "₆", "7", "₇", "8", "₈", "9", "₉")
+
+// Multiplicity returns 2S+1
+func (t TermSymbol) Multiplicity() int {
+	return t.TwoS + 1
+}
+
+// ToString returns the term with a superscript multiplicity and subscript J (e.g., "⁵D₄" or "⁴I₉/₂")
+func (t TermSymbol) ToString() string {
+	j := fmt.Sprintf("%d", t.TwoJ/2)
+	if t.TwoJ%2 == 1 {
+		j = fmt.Sprintf("%d/2", t.TwoJ)
+	}
+	return t.LS() + subscripts.Replace(j)
+}
+
+// LS returns the term without its J (e.g., "⁵D")
+func (t TermSymbol) LS() string {
+	if t.L >= len(orbitalLetters) {
+		return superscripts.Replace(fmt.Sprintf("%d", t.Multiplicity())) + "?"
+	}
+	return superscripts.Replace(fmt.Sprintf("%d", t.Multiplicity())) + string(orbitalLetters[t.L])
+}
+
+// openSubshells returns the subshells of the configuration that are neither empty nor full
+func (ec ElectronConfiguration) openSubshells() []Subshell {
+	var open []Subshell
+	for _, subshell := range ec.Subshells {
+		capacity := 2 * (2*getAzimuthalQuantumNumber(subshell.Orbital) + 1)
+		if subshell.Electrons > 0 && subshell.Electrons < capacity {
+			open = append(open, subshell)
+		}
+	}
+	return open
+}
+
+// GroundTerm returns the ground-state term of the configuration by Hund's rules: the open subshells are filled
+// for maximum S and then maximum L, and J is |L-S| unless no open subshell is less than half full, when it is L+S.
+// Closed-shell configurations are ¹S₀.
+func (ec ElectronConfiguration) GroundTerm() TermSymbol {
+	open := ec.openSubshells()
+	twoS, ml := 0, 0
+	lessThanHalf := false
+	for _, subshell := range open {
+		l := getAzimuthalQuantumNumber(subshell.Orbital)
+		for _, numbers := range (ElectronConfiguration{Subshells: []Subshell{subshell}}).QuantumNumbers() {
+			twoS += int(2 * numbers.S)
+			ml += numbers.M
+		}
+		if subshell.Electrons < 2*l+1 {
+			lessThanHalf = true
+		}
+	}
+
+	term := TermSymbol{TwoS: twoS, L: ml}
+	if lessThanHalf {
+		term.TwoJ = abs(2*term.L - term.TwoS)
+	} else {
+		term.TwoJ = 2*term.L + term.TwoS
+	}
+	return term
+}
+
+// TermSymbol returns the ground-state term of the element, or of its ion if it is charged
+func (el Element) TermSymbol() TermSymbol {
+	return el.GetElectronConfiguration().GroundTerm()
+}
+
+// microstates counts the microstates of electrons in a subshell of azimuthal number l by their total M_L and 2M_S
+func microstates(l, electrons int) map[[2]int]int {
+	counts := make(map[[2]int]int)
+	spinOrbitals := 2 * (2*l + 1)
+	var choose func(next, left, ml, twoMs int)
+	choose = func(next, left, ml, twoMs int) {
+		if left == 0 {
+			counts[[2]int{ml, twoMs}]++
+			return
+		}
+		for i := next; i <= spinOrbitals-left; i++ {
+			// Spin-orbital i is m = l - i/2 with spin up for even i
+			spin := 1
+			if i%2 == 1 {
+				spin = -1
+			}
+			choose(i+1, left-1, ml+l-i/2, twoMs+spin)
+		}
+	}
+	choose(0, electrons, 0, 0)
+	return counts
+}
+
+// Terms returns every level of every term arising from the open subshells of the configuration, ground state first:
+// highest multiplicity, then highest L, then J as Hund's third rule orders it. Closed-shell configurations give only ¹S₀.
+func (ec ElectronConfiguration) Terms() []TermSymbol {
+	// Combine the microstates of each open subshell
+	counts := map[[2]int]int{{0, 0}: 1}
+	for _, subshell := range ec.openSubshells() {
+		combined := make(map[[2]int]int)
+		for a, countA := range counts {
+			for b, countB := range microstates(getAzimuthalQuantumNumber(subshell.Orbital), subshell.Electrons) {
+				combined[[2]int{a[0] + b[0], a[1] + b[1]}] += countA * countB
+			}
+		}
+		counts = combined
+	}
+
+	// Peel off terms from the largest M_L, each taking one microstate of every (M_L, M_S) it spans
+	var lsTerms []TermSymbol
+	for {
+		found := false
+		var top [2]int
+		for key, count := range counts {
+			if count > 0 && (!found || key[0] > top[0] || (key[0] == top[0] && key[1] > top[1])) {
+				top, found = key, true
+			}
+		}
+		if !found {
+			break
+		}
+		L, twoS := top[0], top[1]
+		for ml := -L; ml <= L; ml++ {
+			for twoMs := -twoS; twoMs <= twoS; twoMs += 2 {
+				counts[[2]int{ml, twoMs}]--
+			}
+		}
+		lsTerms = append(lsTerms, TermSymbol{TwoS: twoS, L: L})
+	}
+	sort.SliceStable(lsTerms, func(i, j int) bool {
+		if lsTerms[i].TwoS != lsTerms[j].TwoS {
+			return lsTerms[i].TwoS > lsTerms[j].TwoS
+		}
+		return lsTerms[i].L > lsTerms[j].L
+	})
+
+	// The ground term's J decides whether levels run up or down
+	ground := ec.GroundTerm()
+	inverted := ground.TwoJ == 2*ground.L+ground.TwoS && ground.L > 0 && ground.TwoS > 0
+	var terms []TermSymbol
+	for _, term := range lsTerms {
+		lo, hi := abs(2*term.L-term.TwoS), 2*term.L+term.TwoS
+		for twoJ := lo; twoJ <= hi; twoJ += 2 {
+			if inverted {
+				term.TwoJ = lo + hi - twoJ
+			} else {
+				term.TwoJ = twoJ
+			}
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// abs returns the absolute value of an integer
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
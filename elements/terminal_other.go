@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package elements
+
+import "os"
+
+// terminalWidth returns the width of the terminal f, or 0 if it can't be determined
+func terminalWidth(f *os.File) int {
+	return 0
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// reportPage is the standalone HTML report of a formula
+var reportPage = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; margin: 2em; color: #212121; }
+figure { margin: 0 0 2em 0; overflow-x: auto; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th { text-align: left; padding: 0.25em 1.5em 0.25em 0; font-weight: 600; vertical-align: top; }
+td { padding: 0.25em 0; white-space: pre-line; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{with .Table}}<figure>
+{{.}}</figure>
+{{end}}<table>
+{{range .Rows}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</table>
+{{with .Properties}}<h2>Properties</h2>
+<table>
+{{range .}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</table>
+{{end}}{{with .Configurations}}<h2>Electron configurations</h2>
+<table>
+{{range .}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</table>
+{{end}}{{with .Orbitals}}<h2>Orbital diagrams</h2>
+<table>
+{{range .}}<tr><th>{{index . 0}}</th><td><pre>{{index . 1}}</pre></td></tr>
+{{end}}</table>
+{{end}}{{with .Excitations}}<h2>Excited configurations</h2>
+<table>
+{{range .}}<tr><th>{{index . 0}}</th><td><pre>{{index . 1}}</pre></td></tr>
+{{end}}</table>
+{{end}}</body>
+</html>
+`))
+
+// writeHTML writes the report of a compound as a standalone HTML page, with the same sections as the
+// terminal output: the periodic table as inline SVG if drawTable is set, electron configurations if configs is set,
+// orbital diagrams if orbitals is set and that many excited configurations of each species if excited is positive
+func writeHTML(w io.Writer, compound elements.Compound, formula string, drawTable, configs, orbitals bool, excited int, view configurationView, opts elements.TableOptions) error {
+	page := struct {
+		Title          string
+		Table          template.HTML
+		Rows           [][2]string
+		Properties     [][2]string
+		Configurations [][2]string
+		Orbitals       [][2]string
+		Excitations    [][2]string
+	}{
+		Title: compound.ToString(),
+		Rows:  compoundRows(compound, formula),
+	}
+
+	if drawTable {
+		var svg bytes.Buffer
+		if err := elements.WriteSVG(&svg, compound.ToMolecule(), opts); err != nil {
+			return err
+		}
+		page.Table = template.HTML(svg.String())
+	}
+	if el, exists := elements.ElementTable[formula]; exists {
+		page.Properties = propertyRows(el)
+	}
+	if configs {
+		page.Configurations = configurationRows(compound, view)
+	}
+	if orbitals {
+		page.Orbitals = orbitalRows(compound, view)
+	}
+	if excited > 0 {
+		page.Excitations = excitationRows(compound, view, excited)
+	}
+
+	return reportPage.Execute(w, page)
+}
@@ -5,12 +5,12 @@ go 1.22.3
 require (
 	github.com/alecthomas/participle v0.7.1
 	github.com/mattn/go-colorable v0.1.13
+	github.com/mattn/go-isatty v0.0.16
+	golang.org/x/sys v0.0.0-20220811171246-fbc7d0a398ab
 )
 
 require (
-	github.com/mattn/go-isatty v0.0.16 // indirect
 	github.com/remyoudompheng/bigfft v0.0.0-20200410134404-eec4a21b6bb0 // indirect
-	golang.org/x/sys v0.0.0-20220811171246-fbc7d0a398ab // indirect
 	modernc.org/golex v1.0.5 // indirect
 	modernc.org/goyacc v1.0.3 // indirect
 	modernc.org/mathutil v1.5.0 // indirect
@@ -0,0 +1,37 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+)
+
+// isoelectronicCommand lists the atoms and ions with the same electron configuration as an element or ion
+func isoelectronicCommand(args []string) error {
+	fs := flag.NewFlagSet("isoelectronic", flag.ExitOnError)
+	args = parseArgs(fs, args)
+	if len(args) != 1 {
+		return errors.New("usage: atomic isoelectronic <element or ion>")
+	}
+
+	el, err := parseSpecies(args[0])
+	if err != nil {
+		return err
+	}
+	configuration := el.GetElectronConfiguration()
+
+	fmt.Println()
+	fmt.Printf("  %d electrons: %s\n\n", configuration.Electrons(), configuration.Abbreviated())
+	fmt.Println("  Species   Z     Radius (pm)")
+	for _, species := range el.IsoelectronicSeries() {
+		radius := "-"
+		if species.Charge == 0 && species.AtomicRadius.Valid {
+			radius = species.AtomicRadius.ToString() + " (atomic)"
+		} else if r, ok := species.IonicRadii[species.Charge]; ok {
+			radius = fmt.Sprintf("%g", r)
+		}
+		fmt.Printf("  %-9s %-5d %s\n", species.ToString(), species.Number, radius)
+	}
+	fmt.Println()
+	return nil
+}
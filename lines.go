@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// linesCommand draws the emission spectrum of an element, or identifies the elements behind measured wavelengths
+func linesCommand(args []string) error {
+	fs := flag.NewFlagSet("lines", flag.ExitOnError)
+	tolerance := fs.Float64("tol", 0.5, "Tolerance in nm when identifying measured wavelengths")
+	styleName := fs.String("style", "auto", "Output style: auto, color or plain")
+	args = parseArgs(fs, args)
+	if len(args) == 0 {
+		return errors.New("usage: atomic lines <element> | atomic lines <wavelength nm>... [-tol nm]")
+	}
+
+	style, err := elements.ParseStyle(*styleName)
+	if err != nil {
+		return err
+	}
+	r := elements.NewRenderer(os.Stdout, style)
+
+	// Measured wavelengths are numbers, anything else is an element
+	var measured []float64
+	for _, arg := range args {
+		wavelength, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			break
+		}
+		measured = append(measured, wavelength)
+	}
+	if len(measured) == len(args) {
+		return identifyLines(r, measured, *tolerance)
+	}
+	if len(args) != 1 {
+		return errors.New("usage: atomic lines <element> | atomic lines <wavelength nm>... [-tol nm]")
+	}
+
+	el, err := parseSpecies(args[0])
+	if err != nil {
+		return err
+	}
+	lines, ok := elements.LineTable[el.Symbol]
+	if !ok {
+		return fmt.Errorf("no emission lines of %s in the data", el.Symbol)
+	}
+
+	fmt.Fprintln(r.Out)
+	fmt.Fprintf(r.Out, "  %s emission lines\n\n", el.Name)
+	fmt.Fprintln(r.Out, "  Wavelength (nm)   Intensity")
+	for _, line := range lines {
+		note := ""
+		if line.Wavelength < elements.VisibleMin {
+			note = "  ultraviolet"
+		} else if line.Wavelength > elements.VisibleMax {
+			note = "  infrared"
+		}
+		fmt.Fprintln(r.Out, strings.TrimRight(fmt.Sprintf("  %-17.3f %-9g%s", line.Wavelength, line.Intensity, note), " "))
+	}
+	fmt.Fprintln(r.Out)
+	r.SpectrumBand(lines)
+	fmt.Fprintln(r.Out)
+	return nil
+}
+
+// identifyLines prints the elements that explain the measured wavelengths, best first
+func identifyLines(r *elements.Renderer, measured []float64, tolerance float64) error {
+	if err := loadData(); err != nil {
+		return err
+	}
+
+	matches, err := elements.IdentifyLines(measured, tolerance)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(r.Out)
+	if len(matches) == 0 {
+		fmt.Fprintf(r.Out, "  No element has a line within %g nm of the measured wavelengths\n\n", tolerance)
+		return nil
+	}
+
+	fmt.Fprintf(r.Out, "  Element   Explains   Lines within %g nm\n", tolerance)
+	explained := make([]bool, len(measured))
+	for _, match := range matches {
+		var lines []string
+		for i, line := range match.Lines {
+			if line.Symbol != "" {
+				lines = append(lines, fmt.Sprintf("%g → %.3f (%g)", measured[i], line.Wavelength, line.Intensity))
+				explained[i] = true
+			}
+		}
+		fmt.Fprintf(r.Out, "  %-9s %-10s %s\n", match.Symbol, fmt.Sprintf("%d of %d", match.Explained, len(measured)), strings.Join(lines, ", "))
+	}
+
+	var unexplained []string
+	for i, ok := range explained {
+		if !ok {
+			unexplained = append(unexplained, fmt.Sprintf("%g", measured[i]))
+		}
+	}
+	if len(unexplained) > 0 {
+		fmt.Fprintf(r.Out, "\n  Unexplained: %s nm\n", strings.Join(unexplained, ", "))
+	}
+	fmt.Fprintln(r.Out)
+
+	var band []elements.EmissionLine
+	for _, wavelength := range measured {
+		band = append(band, elements.EmissionLine{Wavelength: wavelength, Intensity: 1})
+	}
+	r.SpectrumBand(band)
+	fmt.Fprintln(r.Out)
+	return nil
+}
@@ -5,6 +5,10 @@ import (
 	"flag"
 	"fmt"
 	"github.com/mahdin-hc/atomic/elements"
+	"io"
+	"os"
+	"sort"
+	"strings"
 )
 
 //go:embed data/elements.csv
@@ -13,9 +17,63 @@ var elementsCSV string
 //go:embed data/molecules.csv
 var moleculesCSV string
 
+//go:embed data/lines.csv
+var linesCSV string
+
+//go:embed data/nuclides.csv
+var nuclidesCSV string
+
+var (
+	elementsFile  = flag.String("elements-file", "", "CSV of elements to add to or override the built-in data")
+	moleculesFile = flag.String("molecules-file", "", "CSV of molecules to add to or override the built-in data")
+	linesFile     = flag.String("lines-file", "", "CSV of emission lines to add to or override the built-in data")
+	nuclidesFile  = flag.String("nuclides-file", "", "CSV of nuclides to add to or override the built-in data")
+)
+
+// commands maps subcommand names to their handlers; any other argument is parsed as a formula
+var commands = map[string]func(args []string) error{
+	"binding":       bindingCommand,
+	"chain":         chainCommand,
+	"config":        configCommand,
+	"data":          dataCommand,
+	"decay":         decayCommand,
+	"isoelectronic": isoelectronicCommand,
+	"lines":         linesCommand,
+	"nuclide":       nuclideCommand,
+	"nuclides":      nuclidesCommand,
+	"qn":            qnCommand,
+	"spectrum":      spectrumCommand,
+	"terms":         termsCommand,
+}
+
+// parseArgs parses flags wherever they appear among a subcommand's arguments, so that
+// "atomic qn Fe -json" works as well as "atomic qn -json Fe", and returns the positional arguments
+func parseArgs(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
 func main() {
 	ptCmd := flag.Bool("pt", false, "Draw periodic table")
 	eCmd := flag.Bool("e", false, "Show electron configurations")
+	orbitalsCmd := flag.Bool("orbitals", false, "Show orbital box diagrams and unpaired electrons")
+	excitedCmd := flag.Int("excited", 0, "Show this many low-lying excited configurations of each atom or ion")
+	shortCmd := flag.Bool("short", false, "Abbreviate electron configurations with a noble-gas core, e.g. [Ar] 4s2 3d6")
+	shellsCmd := flag.Bool("shells", false, "Show electron configurations as electrons per shell, e.g. 2, 8, 14, 2")
+	order := flag.String("order", "filling", "Order subshells of electron configurations by filling order (filling) or by shell (n)")
+	wideCmd := flag.Bool("wide", false, "Draw the periodic table as tiles with atomic numbers and masses")
+	longCmd := flag.Bool("long", false, "Draw the 32-column periodic table with the f-block inline")
+	colorBy := flag.String("color-by", "", "Colour the periodic table by "+strings.Join(elements.ColorProperties, "|"))
+	format := flag.String("format", "text", "Output format: text, svg (periodic table only) or html")
+	styleName := flag.String("style", "auto", "Output style: auto (colour on a terminal unless NO_COLOR is set), color or plain")
 	flag.Parse()
 	args := flag.Args()
 
@@ -23,20 +81,41 @@ func main() {
 	if len(args) == 0 {
 		return
 	}
-	
+
+	if cmd, ok := commands[args[0]]; ok {
+		if err := cmd(args[1:]); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	formula := args[0] // First non-flag argument is the formula
 
-	// Load elements data from CSV
-	err := elements.LoadElements(elementsCSV)
+	// A nuclear equation may be given unquoted, as several arguments
+	if elements.IsNuclearEquation(strings.Join(args, " ")) {
+		formula = strings.Join(args, " ")
+	}
+
+	style, err := elements.ParseStyle(*styleName)
 	if err != nil {
-		fmt.Printf("Error loading elements: %v\n", err)
+		fmt.Println(err)
 		return
 	}
+	r := elements.NewRenderer(os.Stdout, style)
 
-	// Load molecules data from CSV
-	err = elements.LoadMolecules(moleculesCSV)
+	// Load the built-in data and any user overlays
+	err = loadData()
 	if err != nil {
-		fmt.Println("Error loading molecules:", err)
+		fmt.Printf("Error: %v\n", err)
+		return
+	}
+
+	if elements.IsNuclearEquation(formula) {
+		if err := printNuclearEquation(r.Out, formula); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
 		return
 	}
 
@@ -47,51 +126,306 @@ func main() {
 		return
 	}
 
+	view := configurationView{short: *shortCmd, shells: *shellsCmd}
+	switch *order {
+	case "filling":
+	case "n":
+		view.byShell = true
+	default:
+		fmt.Printf("unknown order %q, expected filling or n\n", *order)
+		return
+	}
+
 	molecule := compound.ToMolecule()
+	drawTable := *ptCmd || *wideCmd || *longCmd || *colorBy != ""
+	opts := elements.TableOptions{ColorBy: *colorBy, Tiles: *wideCmd, Long: *longCmd}
 
-	fmt.Println()
+	switch *format {
+	case "text":
+	case "svg":
+		if err := elements.WriteSVG(os.Stdout, molecule, opts); err != nil {
+			fmt.Println(err)
+		}
+		return
+	case "html":
+		if err := writeHTML(os.Stdout, compound, formula, drawTable, *eCmd, *orbitalsCmd, *excitedCmd, view, opts); err != nil {
+			fmt.Println(err)
+		}
+		return
+	default:
+		fmt.Printf("unknown format %q, expected text, svg or html\n", *format)
+		return
+	}
+
+	fmt.Fprintln(r.Out)
 
 	// Draw the periodic table if the draw command is used
-	if *ptCmd {
-		elements.DrawPeriodicTable(molecule)
-		fmt.Println()
-	}
-	
-	// fmt.Printf("%+v\n\n",compound)
-	// for i, m := range compound.Molecules {
-		// fmt.Printf("%d %+v\n", i, m.ToString())
-	// }
-	// fmt.Println("")
-
-	// Output chemical information
+	if drawTable {
+		err := r.PeriodicTable(molecule, opts)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Fprintln(r.Out)
+	}
+
+	printCompound(r.Out, compound, formula)
+
+	// Show electron configurations if -e is passed
+	if *eCmd {
+		printConfigurations(r.Out, compound, view)
+	}
+
+	// Show orbital box diagrams if -orbitals is passed
+	if *orbitalsCmd {
+		if *eCmd {
+			fmt.Fprintln(r.Out)
+		}
+		printOrbitals(r.Out, compound, view)
+	}
+
+	// Show excited configurations if -excited is passed
+	if *excitedCmd > 0 {
+		if *eCmd || *orbitalsCmd {
+			fmt.Fprintln(r.Out)
+		}
+		printExcitations(r.Out, compound, view, *excitedCmd)
+	}
+}
+
+// compoundRows returns the chemical information of a compound, and the details of a single element
+func compoundRows(compound elements.Compound, formula string) [][2]string {
 	name := compound.GetName()
 	el, exists := elements.ElementTable[formula]
 	if exists {
 		name = el.Name
 	}
-	fmt.Printf("  Molecule : %s\n", compound.ToString())
-	fmt.Printf("  Simplify : %s\n", compound.ToMolecule().Simplify())
-	fmt.Printf("  Name     : %s\n", name)
+	rows := [][2]string{
+		{"Molecule", compound.ToString()},
+		{"Simplify", compound.ToMolecule().Simplify()},
+		{"Name", name},
+	}
 	if compound.State != "" {
-		fmt.Printf("  State    : %s\n", compound.State)
+		rows = append(rows, [2]string{"State", compound.State})
 	}
-	fmt.Printf("  Mass     : %f\n", compound.GetMass())
-	fmt.Printf("  Charge   : %d\n", compound.GetCharge())
+	rows = append(rows,
+		[2]string{"Mass", fmt.Sprintf("%f", compound.GetMass())},
+		[2]string{"Charge", fmt.Sprintf("%d", compound.GetCharge())},
+	)
 	if exists {
-		fmt.Printf("  Number   : %d\n", el.Number)
-		fmt.Printf("  Category : %s\n", el.Category)
-		fmt.Printf("  Fact     : %s\n", el.Fact)
+		rows = append(rows,
+			[2]string{"Number", fmt.Sprintf("%d", el.Number)},
+			[2]string{"Category", el.Category},
+			[2]string{"Fact", el.Fact},
+		)
 	}
-	fmt.Println()
+	return rows
+}
 
-	// Show electron configurations if -e is passed
-	if *eCmd {
-		printed := make(map[string]bool)
-		for _, el := range molecule.Elements {
-			if !printed[el.Symbol] {
-				fmt.Printf("  %s(%d): %s\n", el.Symbol, el.Number, el.GetElectronConfiguration().ToString())
-				printed[el.Symbol] = true
+// printCompound prints the chemical information of a compound, and the details of a single element
+func printCompound(w io.Writer, compound elements.Compound, formula string) {
+	for _, row := range compoundRows(compound, formula) {
+		fmt.Fprintf(w, "  %-8s : %s\n", row[0], row[1])
+	}
+	if el, exists := elements.ElementTable[formula]; exists {
+		fmt.Fprintln(w)
+		for _, row := range propertyRows(el) {
+			fmt.Fprintf(w, "  %-17s : %s\n", row[0], row[1])
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+// configurationView selects how electron configurations are written
+type configurationView struct {
+	short   bool // Abbreviate with a noble-gas core
+	shells  bool // Electrons per shell instead of subshells
+	byShell bool // Order subshells by n instead of filling order
+}
+
+// format writes an electron configuration in the selected view
+func (v configurationView) format(ec elements.ElectronConfiguration) string {
+	if v.shells {
+		var shells []string
+		for _, count := range ec.Shells() {
+			shells = append(shells, fmt.Sprintf("%d", count))
+		}
+		return strings.Join(shells, ", ")
+	}
+	if v.byShell {
+		ec = ec.ByShell()
+	}
+	if v.short {
+		return ec.Abbreviated()
+	}
+	return ec.ToString()
+}
+
+// configurationSpecies returns each element or ion in the compound once, followed by the metal ion
+// at the centre of a charged complex
+func configurationSpecies(compound elements.Compound) []elements.Element {
+	var species []elements.Element
+	seen := make(map[string]bool)
+	add := func(el elements.Element) {
+		if !seen[el.ToString()] {
+			seen[el.ToString()] = true
+			species = append(species, el)
+		}
+	}
+
+	for _, el := range compound.ToMolecule().Elements {
+		add(el)
+	}
+	if ion, ok := compound.CentralIon(); ok {
+		add(ion)
+	}
+	return species
+}
+
+// configurationRows returns the electron configuration of each species in the compound
+func configurationRows(compound elements.Compound, view configurationView) [][2]string {
+	var rows [][2]string
+	for _, el := range configurationSpecies(compound) {
+		configuration := view.format(el.GetElectronConfiguration()) + "  " + el.TermSymbol().ToString()
+		if iso, ok := el.IsoelectronicElement(); ok {
+			configuration += fmt.Sprintf(" (isoelectronic with %s)", iso.Symbol)
+		}
+		if valence, ok := el.GetElectronConfiguration().OutermostSubshell(); ok {
+			if shielding, err := el.EffectiveNuclearCharge(valence.Orbital); err == nil {
+				configuration += "\n" + shielding.ToString()
+			}
+		}
+		rows = append(rows, [2]string{fmt.Sprintf("%s(%d)", el.ToString(), el.Number), configuration})
+	}
+	return rows
+}
+
+// orbitalRows returns the orbital box diagram of each species in the compound, with its unpaired electrons
+func orbitalRows(compound elements.Compound, view configurationView) [][2]string {
+	var rows [][2]string
+	for _, el := range configurationSpecies(compound) {
+		configuration := el.GetElectronConfiguration()
+		if view.byShell {
+			configuration = configuration.ByShell()
+		}
+		magnetism := "diamagnetic"
+		if configuration.Paramagnetic() {
+			magnetism = "paramagnetic"
+		}
+		diagram := fmt.Sprintf("%s\nUnpaired electrons: %d (%s)", configuration.OrbitalDiagram(), configuration.UnpairedElectrons(), magnetism)
+		rows = append(rows, [2]string{fmt.Sprintf("%s(%d)", el.ToString(), el.Number), diagram})
+	}
+	return rows
+}
+
+// printOrbitals prints the orbital box diagram of each species in the compound
+func printOrbitals(w io.Writer, compound elements.Compound, view configurationView) {
+	for _, row := range orbitalRows(compound, view) {
+		fmt.Fprintf(w, "  %s:\n", row[0])
+		for _, line := range strings.Split(row[1], "\n") {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// printConfigurations prints the electron configuration of each element or ion in the compound once
+func printConfigurations(w io.Writer, compound elements.Compound, view configurationView) {
+	for _, row := range configurationRows(compound, view) {
+		lines := strings.Split(row[1], "\n")
+		fmt.Fprintf(w, "  %s: %s\n", row[0], lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(w, "    %s\n", line)
+		}
+	}
+}
+
+// excitationRows returns the lowest excited configurations of each species in the compound, one per line with
+// the promotion, its energy and its terms
+func excitationRows(compound elements.Compound, view configurationView, limit int) [][2]string {
+	var rows [][2]string
+	for _, el := range configurationSpecies(compound) {
+		excitations := el.ExcitedConfigurations()
+		if len(excitations) > limit {
+			excitations = excitations[:limit]
+		}
+		var lines []string
+		for _, e := range excitations {
+			var terms []string
+			seen := make(map[string]bool)
+			for _, term := range e.Terms {
+				if !seen[term.LS()] {
+					seen[term.LS()] = true
+					terms = append(terms, term.LS())
+				}
+			}
+			lines = append(lines, fmt.Sprintf("%-28s %-8s %6.2f eV  %s", view.format(e.Configuration), e.From+"→"+e.To, e.Energy, strings.Join(terms, " ")))
+		}
+		rows = append(rows, [2]string{fmt.Sprintf("%s(%d)", el.ToString(), el.Number), strings.Join(lines, "\n")})
+	}
+	return rows
+}
+
+// printExcitations prints the lowest excited configurations of each species in the compound with their terms
+func printExcitations(w io.Writer, compound elements.Compound, view configurationView, limit int) {
+	for _, row := range excitationRows(compound, view, limit) {
+		fmt.Fprintf(w, "  %s excited configurations:\n", row[0])
+		if row[1] != "" {
+			for _, line := range strings.Split(row[1], "\n") {
+				fmt.Fprintf(w, "    %s\n", line)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// propertyRows returns the physical properties of an element, skipping values missing from the data
+func propertyRows(el elements.Element) [][2]string {
+	var rows [][2]string
+	addOptional := func(label string, o elements.Optional, unit string) {
+		if o.Valid {
+			rows = append(rows, [2]string{label, o.ToString() + unit})
+		}
+	}
+
+	addOptional("Electronegativity", el.Electronegativity, " (Pauling)")
+	addOptional("Covalent radius", el.CovalentRadius, " pm")
+	addOptional("Atomic radius", el.AtomicRadius, " pm")
+	if len(el.IonicRadii) > 0 {
+		var charges []int
+		for charge := range el.IonicRadii {
+			charges = append(charges, charge)
+		}
+		sort.Ints(charges)
+		var radii []string
+		for _, charge := range charges {
+			ion := elements.Element{Symbol: el.Symbol, Charge: charge}
+			radii = append(radii, fmt.Sprintf("%s %g pm", ion.ToString(), el.IonicRadii[charge]))
+		}
+		rows = append(rows, [2]string{"Ionic radii", strings.Join(radii, ", ")})
+	}
+	if el.IonizationEnergy[0].Valid {
+		var energies []string
+		for _, ie := range el.IonizationEnergy {
+			energies = append(energies, ie.ToString())
+		}
+		rows = append(rows, [2]string{"Ionization energy", strings.Join(energies, ", ") + " kJ/mol"})
+	}
+	addOptional("Electron affinity", el.ElectronAffinity, " kJ/mol")
+	addOptional("Density", el.Density, " g/cm³")
+	addOptional("Melting point", el.MeltingPoint, " K")
+	addOptional("Boiling point", el.BoilingPoint, " K")
+	if len(el.OxidationStates) > 0 {
+		var states []string
+		for _, state := range el.OxidationStates {
+			if state > 0 {
+				states = append(states, fmt.Sprintf("+%d", state))
+			} else {
+				states = append(states, fmt.Sprintf("%d", state))
 			}
 		}
+		rows = append(rows, [2]string{"Oxidation states", strings.Join(states, ", ")})
 	}
+	addOptional("Discovered", el.Discovered, "")
+	return rows
 }
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// nuclideCommand prints the composition, mass, abundance and decay of a nuclide
+func nuclideCommand(args []string) error {
+	fs := flag.NewFlagSet("nuclide", flag.ExitOnError)
+	args = parseArgs(fs, args)
+	if len(args) != 1 {
+		return errors.New("usage: atomic nuclide <nuclide>, e.g. atomic nuclide U-238")
+	}
+
+	n, err := parseNuclide(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("  %-15s : %s (%s)\n", "Nuclide", n.Name(), n.ToString())
+	fmt.Printf("  %-15s : %d\n", "Protons (Z)", n.Z)
+	fmt.Printf("  %-15s : %d\n", "Neutrons (N)", n.N)
+	fmt.Printf("  %-15s : %d\n", "Mass number (A)", n.A)
+	fmt.Printf("  %-15s : %g u\n", "Atomic mass", n.Mass)
+	be := n.BindingEnergy()
+	fmt.Printf("  %-15s : %.3f MeV, %.3f MeV per nucleon\n", "Binding energy", be.Total, be.PerNucleon)
+	if n.Abundance.Valid {
+		fmt.Printf("  %-15s : %g%%\n", "Abundance", n.Abundance.Value)
+	} else {
+		fmt.Printf("  %-15s : -\n", "Abundance")
+	}
+
+	if n.Stable() {
+		fmt.Printf("  %-15s : stable\n", "Half-life")
+	} else {
+		fmt.Printf("  %-15s : %s\n", "Half-life", elements.FormatDuration(n.HalfLife.Value))
+		fmt.Printf("  %-15s : %s /s\n", "Decay constant", elements.FormatNumber(n.DecayConstant()))
+		for i, decay := range n.Decays {
+			if i == 0 {
+				fmt.Printf("  %-15s : %s\n", "Decay", formatDecay(decay))
+			} else {
+				fmt.Printf("  %-15s   %s\n", "", formatDecay(decay))
+			}
+		}
+	}
+
+	var isotopes []string
+	for _, other := range n.Isotopes() {
+		isotopes = append(isotopes, other.ToString())
+	}
+	if len(isotopes) > 0 {
+		fmt.Printf("  %-15s : %s\n", "Other nuclides", strings.Join(isotopes, ", "))
+	}
+	fmt.Println()
+	return nil
+}
+
+// formatDecay writes a decay branch with its percentage and daughter (e.g. "β- 89.28% → Ca-40")
+func formatDecay(decay elements.Decay) string {
+	s := fmt.Sprintf("%s %s%%", decay.Mode, branchPercent(decay))
+	if decay.Daughter != "" {
+		s += " → " + decay.Daughter
+	}
+	return s
+}
+
+// branchPercent writes the percentage of decays taking a branch to as many places as the data has,
+// so that 99.99995% isn't rounded to 100%
+func branchPercent(decay elements.Decay) string {
+	return strconv.FormatFloat(math.Round(decay.Branch*1e9)/1e7, 'f', -1, 64)
+}
+
+// parseNuclide loads the data and looks up a nuclide
+func parseNuclide(s string) (elements.Nuclide, error) {
+	if err := loadData(); err != nil {
+		return elements.Nuclide{}, err
+	}
+	return elements.ParseNuclide(s)
+}
+
+// printNuclearEquation checks a nuclear equation, completes it if a particle is missing and prints its balance
+func printNuclearEquation(w io.Writer, s string) error {
+	eq, err := elements.ParseNuclearEquation(s)
+	if err != nil {
+		return err
+	}
+	aIn, zIn := 0, 0
+	for _, term := range eq.Reactants {
+		aIn += term.Count * term.Particle.A
+		zIn += term.Count * term.Particle.Z
+	}
+	dA, dZ := eq.Imbalance()
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "  %-15s : %s\n", "Equation", eq.ToString())
+	if eq.Deduced != nil {
+		side := "product"
+		if eq.DeducedReactant {
+			side = "reactant"
+		}
+		fmt.Fprintf(w, "  %-15s : %s, the missing %s\n", "Deduced", eq.Deduced.Particle.Name, side)
+	}
+	fmt.Fprintf(w, "  %-15s : %d → %d\n", "Mass number", aIn, aIn-dA)
+	fmt.Fprintf(w, "  %-15s : %d → %d\n", "Charge", zIn, zIn-dZ)
+	switch lepton := eq.LeptonImbalance(); {
+	case lepton > 0:
+		fmt.Fprintf(w, "  %-15s : not conserved, a neutrino is missing from the products\n", "Lepton number")
+	case lepton < 0:
+		fmt.Fprintf(w, "  %-15s : not conserved, an antineutrino is missing from the products\n", "Lepton number")
+	}
+	if q, ok := eq.Q(); ok {
+		fmt.Fprintf(w, "  %-15s : %.3f MeV\n", "Q value", q)
+	}
+	fmt.Fprintln(w)
+	return nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// nuclidesCommand draws the chart of nuclides, highlighting the nuclides of an optional formula with isotope labels
+func nuclidesCommand(args []string) error {
+	fs := flag.NewFlagSet("nuclides", flag.ExitOnError)
+	zRange := fs.String("z", "", "Atomic numbers to show, e.g. 80:92")
+	nRange := fs.String("n", "", "Neutron numbers to show, e.g. 120:146")
+	colorBy := fs.String("color-by", "decay", "Colour nuclides by "+strings.Join(elements.ChartColorings, "|"))
+	styleName := fs.String("style", "auto", "Output style: auto, color or plain")
+	width := fs.Int("width", 0, "Columns to fit the chart in, by default the terminal's width or 80")
+	args = parseArgs(fs, args)
+	if len(args) > 1 {
+		return fmt.Errorf(`usage: atomic nuclides [formula] [-z lo:hi] [-n lo:hi] [-color-by %s] [-width columns], e.g. atomic nuclides "¹³CH4"`, strings.Join(elements.ChartColorings, "|"))
+	}
+
+	style, err := elements.ParseStyle(*styleName)
+	if err != nil {
+		return err
+	}
+	if err := loadData(); err != nil {
+		return err
+	}
+
+	opts := elements.ChartOptions{ColorBy: *colorBy, Highlight: make(map[string]bool)}
+	if len(args) == 1 {
+		nuclides, _, err := elements.IsotopeLabels(args[0])
+		if err != nil {
+			return err
+		}
+		for _, n := range nuclides {
+			opts.Highlight[n.ToString()] = true
+		}
+	}
+
+	// The window defaults to every nuclide in the data
+	opts.Z, opts.N = [2]int{1 << 30, 0}, [2]int{1 << 30, 0}
+	for _, n := range elements.NuclideTable {
+		opts.Z = [2]int{min(opts.Z[0], n.Z), max(opts.Z[1], n.Z)}
+		opts.N = [2]int{min(opts.N[0], n.N), max(opts.N[1], n.N)}
+	}
+	if *zRange != "" {
+		if opts.Z, err = parseRange(*zRange); err != nil {
+			return err
+		}
+	}
+	if *nRange != "" {
+		if opts.N, err = parseRange(*nRange); err != nil {
+			return err
+		}
+	}
+
+	// Off a terminal there is no width to fit, and the full chart is some 300 columns wide
+	r := elements.NewRenderer(os.Stdout, style)
+	switch {
+	case *width > 0:
+		r.Width = *width
+	case r.Width == 0:
+		r.Width = 80
+	}
+	fmt.Fprintln(r.Out)
+	if err := r.NuclideChart(opts); err != nil {
+		return err
+	}
+	fmt.Fprintln(r.Out)
+	return nil
+}
+
+// parseRange parses an inclusive range of numbers written lo:hi or lo-hi, or a single number
+func parseRange(s string) ([2]int, error) {
+	lo, hi, found := strings.Cut(s, ":")
+	if !found {
+		lo, hi, found = strings.Cut(s, "-")
+	}
+	if !found {
+		hi = lo
+	}
+	a, errLo := strconv.Atoi(strings.TrimSpace(lo))
+	b, errHi := strconv.Atoi(strings.TrimSpace(hi))
+	if errLo != nil || errHi != nil || a < 0 || b < a {
+		return [2]int{}, fmt.Errorf("%q is not a range, write it like 80:92", s)
+	}
+	return [2]int{a, b}, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// electronNumbers is one electron of the qn table, numbered from 1 unlike the 0-based index of GetQuantumNumbers
+type electronNumbers struct {
+	Index   int    `json:"index"`
+	Orbital string `json:"orbital"`
+	elements.QuantumNumbers
+}
+
+// qnCommand prints the quantum numbers of every electron of an element or ion, or of one electron numbered from 1
+func qnCommand(args []string) error {
+	usage := "usage: atomic qn [-json] <element or ion> [electron number, counted from 1]"
+	fs := flag.NewFlagSet("qn", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "Write the quantum numbers as JSON")
+	args = parseArgs(fs, args)
+	if len(args) < 1 || len(args) > 2 {
+		return errors.New(usage)
+	}
+
+	el, err := parseSpecies(args[0])
+	if err != nil {
+		return err
+	}
+
+	configuration := el.GetElectronConfiguration()
+	var electrons []electronNumbers
+	index := 1
+	for _, subshell := range configuration.Subshells {
+		single := elements.ElectronConfiguration{Subshells: []elements.Subshell{subshell}}
+		for _, numbers := range single.QuantumNumbers() {
+			electrons = append(electrons, electronNumbers{index, subshell.Orbital, numbers})
+			index++
+		}
+	}
+
+	if len(args) == 2 {
+		i, err := strconv.Atoi(args[1])
+		if err != nil || i < 1 || i > len(electrons) {
+			return fmt.Errorf("electron number %q out of range, expected 1 to %d", args[1], len(electrons))
+		}
+		electrons = electrons[i-1 : i]
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(electrons)
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s(%d): %s\n\n", el.ToString(), el.Number, configuration.ToString())
+	fmt.Println("  #    Orbital   n   l   m_l   m_s")
+	for _, e := range electrons {
+		spin := "+1/2"
+		if e.S < 0 {
+			spin = "-1/2"
+		}
+		m := fmt.Sprintf("%+d", e.M)
+		if e.M == 0 {
+			m = "0"
+		}
+		fmt.Printf("  %-4d %-9s %-3d %-3d %3s   %s\n", e.Index, e.Orbital, e.N, e.L, m, spin)
+	}
+	fmt.Println()
+	return nil
+}
+
+// parseSpecies loads the data and parses a single element or ion such as Fe or Fe+3
+func parseSpecies(formula string) (elements.Element, error) {
+	if err := loadData(); err != nil {
+		return elements.Element{}, err
+	}
+	compound, err := elements.ParseFormula(formula)
+	if err != nil {
+		return elements.Element{}, err
+	}
+	molecule := compound.ToMolecule()
+	if len(molecule.Elements) != 1 {
+		return elements.Element{}, fmt.Errorf("%s is not a single element or ion", formula)
+	}
+	el := molecule.Elements[0]
+	if el.Number == 0 {
+		return elements.Element{}, fmt.Errorf("unknown element %s", el.Symbol)
+	}
+	el.Charge = compound.GetCharge()
+	return el, nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// spectrumCommand prints the energy levels and a spectral series of a hydrogen-like atom or ion
+func spectrumCommand(args []string) error {
+	var series []string
+	for name := range elements.SpectralSeries {
+		series = append(series, name)
+	}
+	sort.Slice(series, func(i, j int) bool { return elements.SpectralSeries[series[i]] < elements.SpectralSeries[series[j]] })
+
+	fs := flag.NewFlagSet("spectrum", flag.ExitOnError)
+	seriesName := fs.String("series", "balmer", "Series to compute: "+strings.Join(series, ", "))
+	lower := fs.Int("lower", 0, "Lower level of the series, instead of a named series")
+	maxUpper := fs.Int("max", 0, "Highest upper level (default 8 above the lower level)")
+	asCSV := fs.Bool("csv", false, "Write the lines as CSV")
+	styleName := fs.String("style", "auto", "Output style: auto, color or plain")
+	args = parseArgs(fs, args)
+	if len(args) != 1 {
+		return errors.New("usage: atomic spectrum <element> [-series name | -lower n] [-max n] [-csv]")
+	}
+
+	el, err := parseSpecies(args[0])
+	if err != nil {
+		return err
+	}
+	if *lower == 0 {
+		var ok bool
+		*lower, ok = elements.SpectralSeries[strings.ToLower(*seriesName)]
+		if !ok {
+			return fmt.Errorf("unknown series %q, expected one of: %s", *seriesName, strings.Join(series, ", "))
+		}
+	}
+	if *maxUpper == 0 {
+		*maxUpper = *lower + 8
+	}
+	lines, err := el.HydrogenLikeSeries(*lower, *maxUpper)
+	if err != nil {
+		return err
+	}
+
+	if *asCSV {
+		w := csv.NewWriter(os.Stdout)
+		w.Write([]string{"upper", "lower", "wavelength_nm", "frequency_hz", "energy_ev"})
+		for _, line := range lines {
+			w.Write([]string{
+				fmt.Sprint(line.Upper), fmt.Sprint(line.Lower),
+				fmt.Sprintf("%.4f", line.Wavelength), fmt.Sprintf("%.6e", line.Frequency), fmt.Sprintf("%.6f", line.Energy),
+			})
+		}
+		w.Flush()
+		return w.Error()
+	}
+
+	style, err := elements.ParseStyle(*styleName)
+	if err != nil {
+		return err
+	}
+	r := elements.NewRenderer(os.Stdout, style)
+
+	// The spectrum is of the single-electron ion of the element, e.g. He+ for helium
+	ion := el
+	ion.Charge = el.Number - 1
+	fmt.Fprintln(r.Out)
+	fmt.Fprintf(r.Out, "  Hydrogen-like %s, R = %.6e 1/m\n\n", ion.ToString(), el.RydbergConstant())
+	fmt.Fprintln(r.Out, "  Level   Energy (eV)")
+	for n := 1; n <= *maxUpper; n++ {
+		fmt.Fprintf(r.Out, "  %-7d %.4f\n", n, el.EnergyLevel(n))
+	}
+
+	fmt.Fprintln(r.Out)
+	fmt.Fprintln(r.Out, "  Line      Wavelength (nm)   Frequency (Hz)   Energy (eV)")
+	var band []elements.EmissionLine
+	for _, line := range lines {
+		fmt.Fprintf(r.Out, "  %-9s %-17.3f %-16.6e %.4f\n", fmt.Sprintf("%d → %d", line.Upper, line.Lower), line.Wavelength, line.Frequency, line.Energy)
+		band = append(band, elements.EmissionLine{Symbol: el.Symbol, Wavelength: line.Wavelength, Intensity: 1})
+	}
+	fmt.Fprintf(r.Out, "  %-9s %.3f\n\n", "limit", el.SeriesLimit(*lower))
+
+	r.SpectrumBand(band)
+	fmt.Fprintln(r.Out)
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/mahdin-hc/atomic/elements"
+)
+
+// termsCommand prints the ground term and all terms of an element, an ion or a bare subshell such as p2 or d3
+func termsCommand(args []string) error {
+	fs := flag.NewFlagSet("terms", flag.ExitOnError)
+	args = parseArgs(fs, args)
+	if len(args) != 1 {
+		return errors.New("usage: atomic terms <element, ion or subshell such as p2>")
+	}
+
+	var label string
+	var configuration elements.ElectronConfiguration
+	if subshell, ok := parseBareSubshell(args[0]); ok {
+		label = args[0]
+		configuration = elements.ElectronConfiguration{Subshells: []elements.Subshell{subshell}}
+	} else {
+		el, err := parseSpecies(args[0])
+		if err != nil {
+			return err
+		}
+		configuration = el.GetElectronConfiguration()
+		label = fmt.Sprintf("%s(%d): %s", el.ToString(), el.Number, configuration.Abbreviated())
+	}
+
+	fmt.Println()
+	fmt.Printf("  %s\n", label)
+	fmt.Printf("  Ground term: %s\n\n", configuration.GroundTerm().ToString())
+
+	// Group the levels of each term on one line. A term repeats when its first J comes round again.
+	var lines []string
+	var levels []string
+	var first elements.TermSymbol
+	for i, term := range configuration.Terms() {
+		if i == 0 || term.TwoS != first.TwoS || term.L != first.L || term.TwoJ == first.TwoJ {
+			if len(levels) > 0 {
+				lines = append(lines, strings.Join(levels, " "))
+			}
+			levels = nil
+			first = term
+		}
+		levels = append(levels, term.ToString())
+	}
+	lines = append(lines, strings.Join(levels, " "))
+
+	fmt.Println("  Terms:")
+	for _, line := range lines {
+		fmt.Printf("    %s\n", line)
+	}
+	fmt.Println()
+	return nil
+}
+
+// parseBareSubshell parses a subshell without its shell, such as p2 or d3, as the lowest shell that has it
+func parseBareSubshell(s string) (elements.Subshell, bool) {
+	var letter rune
+	var electrons int
+	if n, err := fmt.Sscanf(s, "%c%d", &letter, &electrons); err != nil || n != 2 {
+		return elements.Subshell{}, false
+	}
+	l := strings.IndexRune("spdf", letter)
+	if l < 0 || electrons < 0 || electrons > 2*(2*l+1) || fmt.Sprintf("%c%d", letter, electrons) != s {
+		return elements.Subshell{}, false
+	}
+	return elements.Subshell{Orbital: fmt.Sprintf("%d%c", l+1, letter), Electrons: electrons}, true
+}